@@ -13,6 +13,7 @@ import (
 	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
 	"github.com/elleqt/gotron-sdk/pkg/common"
 	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/ledger"
 	"github.com/elleqt/gotron-sdk/pkg/proto/core"
 	"github.com/elleqt/gotron-sdk/pkg/store"
 	"github.com/spf13/cobra"
@@ -24,6 +25,7 @@ var (
 	resourcesDelegate string
 	voteList          []string
 	permissionList    []string
+	memo              string
 )
 
 func accountSub() []*cobra.Command {
@@ -79,6 +81,9 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -135,6 +140,15 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if len(memo) > 0 {
+				if err := conn.SetMemo(tx, memo); err != nil {
+					return err
+				}
+			}
+
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -160,6 +174,9 @@ func accountSub() []*cobra.Command {
 			result["to"] = addr.String()
 			result["amount"] = value
 			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			if len(memo) > 0 {
+				result["memo"] = memo
+			}
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -173,6 +190,7 @@ func accountSub() []*cobra.Command {
 			return nil
 		},
 	}
+	cmdSend.Flags().StringVar(&memo, "memo", "", "transaction memo, burns the memo fee")
 
 	cmdAddress := &cobra.Command{
 		Use:   "address [ACC_NAME]",
@@ -242,6 +260,9 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -322,6 +343,9 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -400,6 +424,9 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -573,6 +600,9 @@ func accountSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -631,8 +661,21 @@ func accountSub() []*cobra.Command {
 
 			var signature []byte
 			if useLedgerWallet {
-				// TODO:
-				// account := keystore.Account{Address: signerAddress.GetAddress()}
+				// the app hashes the message with its actual length
+				if useFixedLength && len(message) != 32 {
+					return fmt.Errorf("--useFixedLength requires --hashMessage with --ledger")
+				}
+				var err error
+				if signature, err = ledger.SignMessage(message, ledgerOptions...); err != nil {
+					return err
+				}
+				signer, err := keystore.RecoverPubkey(keystore.TextHash(message), append([]byte{}, signature...))
+				if err != nil {
+					return err
+				}
+				if signer.String() != signerAddress.String() {
+					return fmt.Errorf("%w: signed by %s, expected %s", ledger.ErrSignerMismatch, signer, signerAddress.String())
+				}
 			} else {
 				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
 				if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -81,11 +82,71 @@ func init() {
 				fmt.Println(config.APIKey)
 			case "withTLS":
 				fmt.Println(config.WithTLS)
+			case "network":
+				fmt.Println(config.Network)
 			default:
 				return fmt.Errorf("parameter not found")
 			}
 			return nil
 		},
+	}, {
+		Use:   "use <profile>",
+		Short: "select the network profile used by default",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := lookupProfile(args[0]); err != nil {
+				return err
+			}
+			config.Network = args[0]
+			return SaveConfig(config)
+		},
+	}, {
+		Use:   "profiles",
+		Short: "list network profiles, the active one marked with *",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, name := range profileNames() {
+				p, _ := lookupProfile(name)
+				mark := " "
+				if name == config.Network {
+					mark = "*"
+				}
+				fmt.Printf("%s %-10s %s\n", mark, name, p.Node)
+			}
+			return nil
+		},
+	}, {
+		Use:   "profile <profile> [param] [value]",
+		Short: "show a network profile or set one of its params",
+		Long: `show a network profile or set one of its params, creating it when needed.
+params: node, solidityNode, withTLS, apiKey, signer, keystoreDir, feeLimit, deployFeeLimit`,
+		Args: cobra.RangeArgs(1, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				p, err := lookupProfile(args[0])
+				if err != nil {
+					return err
+				}
+				asJSON, _ := json.Marshal(p)
+				fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+				return nil
+			}
+			if len(args) != 3 {
+				return fmt.Errorf("expected <profile> <param> <value>")
+			}
+			if config.Profiles == nil {
+				config.Profiles = make(map[string]*Profile)
+			}
+			p, ok := config.Profiles[args[0]]
+			if !ok {
+				p = &Profile{}
+				config.Profiles[args[0]] = p
+			}
+			if err := setProfileParam(p, args[1], args[2]); err != nil {
+				return err
+			}
+			return SaveConfig(config)
+		},
 	}}...)
 
 	RootCmd.AddCommand(cmdConfig)
@@ -115,6 +176,97 @@ func initConfig() {
 	}
 }
 
+// profileNames sorted presets and configured profiles
+func profileNames() []string {
+	names := make([]string, 0, len(presetProfiles)+len(config.Profiles))
+	for name := range presetProfiles {
+		names = append(names, name)
+	}
+	for name := range config.Profiles {
+		if _, ok := presetProfiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupProfile return the named profile, config fields overriding presets
+func lookupProfile(name string) (*Profile, error) {
+	preset, isPreset := presetProfiles[name]
+	custom, isCustom := config.Profiles[name]
+	if !isPreset && !isCustom {
+		return nil, fmt.Errorf("unknown network profile %s", name)
+	}
+	p := preset
+	if isCustom && custom != nil {
+		if len(custom.Node) > 0 {
+			p.Node = custom.Node
+		}
+		if len(custom.SolidityNode) > 0 {
+			p.SolidityNode = custom.SolidityNode
+		}
+		if custom.WithTLS != nil {
+			p.WithTLS = custom.WithTLS
+		}
+		if len(custom.APIKey) > 0 {
+			p.APIKey = custom.APIKey
+		}
+		if len(custom.Signer) > 0 {
+			p.Signer = custom.Signer
+		}
+		if len(custom.KeystoreDir) > 0 {
+			p.KeystoreDir = custom.KeystoreDir
+		}
+		if custom.FeeLimit > 0 {
+			p.FeeLimit = custom.FeeLimit
+		}
+		if custom.DeployFeeLimit > 0 {
+			p.DeployFeeLimit = custom.DeployFeeLimit
+		}
+	}
+	if len(p.Node) == 0 {
+		return nil, fmt.Errorf("network profile %s has no node", name)
+	}
+	return &p, nil
+}
+
+func setProfileParam(p *Profile, param, value string) error {
+	var err error
+	switch param {
+	case "node":
+		p.Node = withDefaultPort(value)
+	case "solidityNode":
+		p.SolidityNode = withDefaultPort(value)
+	case "withTLS":
+		var tls bool
+		if tls, err = strconv.ParseBool(value); err == nil {
+			p.WithTLS = &tls
+		}
+	case "apiKey":
+		p.APIKey = value
+	case "signer":
+		p.Signer = value
+	case "keystoreDir":
+		p.KeystoreDir = value
+	case "feeLimit":
+		p.FeeLimit, err = strconv.ParseInt(value, 10, 64)
+	case "deployFeeLimit":
+		p.DeployFeeLimit, err = strconv.ParseInt(value, 10, 64)
+	default:
+		return fmt.Errorf("parameter not found")
+	}
+	return err
+}
+
+// withDefaultPort append the default gRPC port to a bare host
+func withDefaultPort(addr string) string {
+	if len(strings.Split(addr, ":")) == 1 {
+		return addr + ":50051"
+	}
+	return addr
+}
+
 // LoadConfig loads config file in yaml format
 func LoadConfig() (*Config, error) {
 	in, err := ioutil.ReadFile(DefaultConfigFile)
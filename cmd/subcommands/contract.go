@@ -79,6 +79,9 @@ func contractSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -108,6 +111,9 @@ func contractSub() []*cobra.Command {
 			result["contractAddress"] = addrResult
 			result["success"] = ctrlr.GetResultError() == nil
 			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+			if revert := conn.RevertReason(ctx, ctrlr.Receipt); revert != nil {
+				result["revert"] = revert
+			}
 			result["receipt"] = map[string]interface{}{
 				"fee":               ctrlr.Receipt.Fee,
 				"energyFee":         ctrlr.Receipt.Receipt.EnergyFee,
@@ -252,6 +258,9 @@ func contractSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -281,6 +290,9 @@ func contractSub() []*cobra.Command {
 			result["contractAddress"] = addrResult
 			result["success"] = ctrlr.GetResultError() == nil
 			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+			if revert := conn.RevertReason(ctx, ctrlr.Receipt); revert != nil {
+				result["revert"] = revert
+			}
 			result["receipt"] = map[string]interface{}{
 				"fee":               ctrlr.Receipt.Fee,
 				"energyFee":         ctrlr.Receipt.Receipt.EnergyFee,
@@ -88,6 +88,9 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -172,6 +175,9 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -258,6 +264,9 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -418,6 +427,9 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -41,8 +42,7 @@ func keysSub() []*cobra.Command {
 		Short: "List all the local accounts",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if useLedgerWallet {
-				ledger.ProcessAddressCommand()
-				return nil
+				return ledger.ProcessAddressCommand(ledgerOptions...)
 			}
 			store.DescribeLocalAccounts()
 			return nil
@@ -278,7 +278,92 @@ func keysSub() []*cobra.Command {
 		cmdExportKS, cmdExportPK, randomPrivateKey, addressFromPrivateKey}
 }
 
+func ledgerSub() []*cobra.Command {
+	cmdDevices := &cobra.Command{
+		Use:   "devices",
+		Short: "List connected Ledger devices and their TRON app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			devices := make([]map[string]interface{}, 0)
+			for _, d := range ledger.Devices() {
+				device := map[string]interface{}{
+					"path":  d.Path,
+					"model": d.Model,
+				}
+				if len(d.Serial) > 0 {
+					device["serial"] = d.Serial
+				}
+				if config, err := ledger.GetAppConfiguration(ledger.WithDevice(d.Path)); err != nil {
+					device["error"] = err.Error()
+				} else {
+					device["app"] = config
+				}
+				devices = append(devices, device)
+			}
+			asJSON, _ := json.Marshal(devices)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	var confirmAddress bool
+	var addressCount uint32
+	cmdAddress := &cobra.Command{
+		Use:   "address",
+		Short: "Show the addresses of Ledger accounts",
+		Long: `Show the address at --ledger-path, m/44'/195'/0'/0/0 by default, and with
+--count the following ones by address index. --confirm shows each address
+on the device to check it was not tampered with.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ledger.DefaultPath
+			if len(ledgerPath) > 0 {
+				var err error
+				if path, err = ledger.ParsePath(ledgerPath); err != nil {
+					return err
+				}
+			}
+			if addressCount == 0 {
+				addressCount = 1
+			}
+			result := make([]map[string]interface{}, 0, addressCount)
+			for i := uint32(0); i < addressCount; i++ {
+				p := append([]uint32{}, path...)
+				p[len(p)-1] += i
+				options := append(append([]func(*ledger.Options){}, ledgerOptions...), ledger.WithPath(p))
+				if confirmAddress {
+					options = append(options, ledger.WithConfirm())
+				}
+				addr, err := ledger.GetAddress(options...)
+				if err != nil {
+					return err
+				}
+				result = append(result, map[string]interface{}{
+					"path":    ledger.FormatPath(p),
+					"address": addr,
+				})
+			}
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdAddress.Flags().BoolVar(&confirmAddress, "confirm", false, "show each address on the device for confirmation")
+	cmdAddress.Flags().Uint32Var(&addressCount, "count", 1, "number of consecutive address indexes to show")
+
+	return []*cobra.Command{cmdDevices, cmdAddress}
+}
+
 func init() {
+	cmdLedger := &cobra.Command{
+		Use:   "ledger",
+		Short: "Ledger devices and accounts",
+		Long:  "Select a device with --ledger-device and an account with --ledger-path",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+	cmdLedger.AddCommand(ledgerSub()...)
+
 	cmdKeys := &cobra.Command{
 		Use:   "keys",
 		Short: "Add or view local private keys",
@@ -290,5 +375,6 @@ func init() {
 	}
 
 	cmdKeys.AddCommand(keysSub()...)
+	cmdKeys.AddCommand(cmdLedger)
 	RootCmd.AddCommand(cmdKeys)
 }
@@ -99,6 +99,9 @@ func proposalSub() []*cobra.Command {
 			if err != nil {
 				return err
 			}
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -154,6 +157,9 @@ func proposalSub() []*cobra.Command {
 			if err != nil {
 				return err
 			}
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -227,6 +233,9 @@ func proposalSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -16,11 +16,13 @@ import (
 	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
 	"github.com/elleqt/gotron-sdk/pkg/common"
 	c "github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/ledger"
 	"github.com/elleqt/gotron-sdk/pkg/store"
 	color "github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh/terminal"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -34,6 +36,9 @@ var (
 	dryRun                 bool
 	noWait                 bool
 	useLedgerWallet        bool
+	ledgerPath             string
+	ledgerDevice           string
+	ledgerOptions          []func(*ledger.Options)
 	noPrettyOutput         bool
 	userProvidesPassphrase bool
 	passphraseFilePath     string
@@ -44,6 +49,8 @@ var (
 	timeout                uint32
 	withTLS                bool
 	apiKey                 string
+	network                string
+	solidityNode           string
 	conn                   *client.Client
 	// RootCmd is single entry point of the CLI
 	RootCmd = &cobra.Command{
@@ -54,11 +61,29 @@ var (
 			if verbose {
 				common.EnableAllVerbose()
 			}
-			switch URLcomponents := strings.Split(node, ":"); len(URLcomponents) {
-			case 1:
-				node = node + ":50051"
+			if common.DebugGRPC {
+				if logger, err := zap.NewDevelopment(); err == nil {
+					zap.ReplaceGlobals(logger)
+				}
+			}
+			if err := applyProfile(cmd); err != nil {
+				return err
+			}
+			if err := parseLedgerFlags(); err != nil {
+				return err
 			}
+			node = withDefaultPort(node)
 			conn = client.New(node)
+			if len(solidityNode) > 0 {
+				conn.SetSolidityAddress(solidityNode)
+			}
+			if envKey, ok := os.LookupEnv("TRONGRID_APIKEY"); ok {
+				apiKey = envKey
+			}
+			if err := conn.SetAPIKey(apiKey); err != nil {
+				return err
+			}
+			conn.SetRetryPolicy(&client.DefaultRetryPolicy)
 
 			// load grpc options
 			opts := make([]grpc.DialOption, 0)
@@ -72,6 +97,11 @@ var (
 				return err
 			}
 
+			if len(defaultKeystoreDir) > 0 {
+				// set default directory
+				store.SetDefaultLocation(defaultKeystoreDir)
+			}
+
 			if len(signer) > 0 {
 				var err error
 				if signerAddress, err = findAddress(signer); err != nil {
@@ -85,11 +115,6 @@ var (
 				return err
 			}
 
-			if len(defaultKeystoreDir) > 0 {
-				// set default directory
-				store.SetDefaultLocation(defaultKeystoreDir)
-			}
-
 			return nil
 		},
 		Long: fmt.Sprintf(`
@@ -112,6 +137,7 @@ func init() {
 	RootCmd.PersistentFlags().StringVarP(&node, "node", "n", config.Node, "<host>")
 	RootCmd.PersistentFlags().StringVarP(&apiKey, "apiKey", "k", config.APIKey, "<api-key>")
 	RootCmd.PersistentFlags().BoolVar(&withTLS, "withTLS", config.WithTLS, "<bool>")
+	RootCmd.PersistentFlags().StringVar(&network, "network", config.Network, "<profile> mainnet, shasta, nile, private or a config profile")
 	RootCmd.PersistentFlags().BoolVar(
 		&noPrettyOutput, "no-pretty", config.NoPretty, "Disable pretty print JSON outputs",
 	)
@@ -120,7 +146,13 @@ func init() {
 	RootCmd.Flags().Uint32Var(&timeout, "timeout", config.Timeout, "set timeout in seconds. Set to 0 to not wait for confirm")
 
 	RootCmd.PersistentFlags().BoolVarP(&useLedgerWallet, "ledger", "e", config.Ledger, "Use ledger hardware wallet")
+	RootCmd.PersistentFlags().StringVar(&ledgerPath, "ledger-path", "", "BIP44 path of the ledger account, m/44'/195'/0'/0/0 by default")
+	RootCmd.PersistentFlags().StringVar(&ledgerDevice, "ledger-device", "", "HID path of the ledger to use when several are connected, see keys ledger devices, or tcp://host:port of a Speculos emulator")
 	RootCmd.PersistentFlags().StringVar(&givenFilePath, "file", "", "Path to file for given command when applicable")
+	RootCmd.PersistentFlags().Int32Var(&permissionID, "permission-id", 0, "account permission signing the transaction, 2 or more for active permissions")
+	RootCmd.PersistentFlags().StringVar(&exportUnsignedPath, "export-unsigned", "", "write the unsigned transaction to a file for offline signing instead of sending it")
+	RootCmd.PersistentFlags().BoolVar(&estimateOnly, "estimate", false, "print the bandwidth, energy and TRX the transaction would cost instead of sending it")
+	RootCmd.PersistentFlags().BoolVar(&simulateOnly, "simulate", false, "run the smart contract call without changing state and print its outcome instead of sending it")
 
 	// Password
 	RootCmd.PersistentFlags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
@@ -249,12 +281,70 @@ func findAddress(value string) (tronAddress, error) {
 	return address, nil
 }
 
+// applyProfile load the selected network profile, explicit flags win
+func applyProfile(cmd *cobra.Command) error {
+	if len(network) == 0 {
+		return nil
+	}
+	p, err := lookupProfile(network)
+	if err != nil {
+		return err
+	}
+	flags := cmd.Flags()
+	if !flags.Changed("node") {
+		node = p.Node
+	}
+	if !flags.Changed("withTLS") {
+		withTLS = p.WithTLS != nil && *p.WithTLS
+	}
+	if !flags.Changed("apiKey") && len(p.APIKey) > 0 {
+		apiKey = p.APIKey
+	}
+	if !flags.Changed("signer") && len(p.Signer) > 0 {
+		signer = p.Signer
+	}
+	if !flags.Changed("ks-dir") && len(p.KeystoreDir) > 0 {
+		defaultKeystoreDir = p.KeystoreDir
+	}
+	solidityNode = p.SolidityNode
+	limit := p.FeeLimit
+	if cmd.Name() == "deploy" {
+		limit = p.DeployFeeLimit
+	}
+	if !flags.Changed("feeLimit") && limit > 0 {
+		feeLimit = limit
+	}
+	return nil
+}
+
+// parseLedgerFlags select the ledger device and account
+func parseLedgerFlags() error {
+	ledgerOptions = nil
+	if len(ledgerDevice) > 0 {
+		ledgerOptions = append(ledgerOptions, ledger.WithDevice(ledgerDevice))
+	}
+	if len(ledgerPath) > 0 {
+		path, err := ledger.ParsePath(ledgerPath)
+		if err != nil {
+			return err
+		}
+		ledgerOptions = append(ledgerOptions, ledger.WithPath(path))
+	}
+	return nil
+}
+
 func opts(ctlr *transaction.Controller) {
+	if len(network) > 0 {
+		fmt.Fprintf(os.Stderr, "network: %s (%s)\n", network, node)
+	} else {
+		fmt.Fprintf(os.Stderr, "node: %s\n", node)
+	}
 	if dryRun {
 		ctlr.Behavior.DryRun = true
 	}
 	if useLedgerWallet {
 		ctlr.Behavior.SigningImpl = transaction.Ledger
+		ctlr.Behavior.LedgerOptions = ledgerOptions
 	}
 	if noWait {
 		ctlr.Behavior.ConfirmationWaitTime = 0
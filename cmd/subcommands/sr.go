@@ -98,6 +98,9 @@ func srSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -155,6 +158,9 @@ func srSub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -122,6 +122,9 @@ func trc10Sub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -212,6 +215,15 @@ func trc10Sub() []*cobra.Command {
 				return err
 			}
 
+			if len(memo) > 0 {
+				if err := conn.SetMemo(tx, memo); err != nil {
+					return err
+				}
+			}
+
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -234,6 +246,9 @@ func trc10Sub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			if len(memo) > 0 {
+				result["memo"] = memo
+			}
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -247,6 +262,7 @@ func trc10Sub() []*cobra.Command {
 			return nil
 		},
 	}
+	cmdSend.Flags().StringVar(&memo, "memo", "", "transaction memo, burns the memo fee")
 
 	cmdICO := &cobra.Command{
 		Use:   "ico <TOKEN_ID or TOKEN_NAME> <AMOUNT>",
@@ -303,6 +319,9 @@ func trc10Sub() []*cobra.Command {
 				return err
 			}
 
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -50,6 +50,15 @@ func trc20Sub() []*cobra.Command {
 				return err
 			}
 
+			if len(memo) > 0 {
+				if err := conn.SetMemo(tx, memo); err != nil {
+					return err
+				}
+			}
+
+			if exported, err := prepareTransaction(ctx, tx); exported || err != nil {
+				return err
+			}
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -74,11 +83,17 @@ func trc20Sub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			if len(memo) > 0 {
+				result["memo"] = memo
+			}
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["contractAddress"] = addrResult
 			result["success"] = ctrlr.GetResultError() == nil
 			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+			if revert := conn.RevertReason(ctx, ctrlr.Receipt); revert != nil {
+				result["revert"] = revert
+			}
 			result["receipt"] = map[string]interface{}{
 				"fee":               ctrlr.Receipt.Fee,
 				"energyFee":         ctrlr.Receipt.Receipt.EnergyFee,
@@ -94,6 +109,7 @@ func trc20Sub() []*cobra.Command {
 			return nil
 		},
 	}
+	cmdSend.Flags().StringVar(&memo, "memo", "", "transaction memo, burns the memo fee")
 
 	cmdBalance := &cobra.Command{
 		Use:     "balance <ADDRESS_TO> <CONTRACT_ADDRESS> ",
@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/elleqt/gotron-sdk/pkg/store"
+	"github.com/elleqt/gotron-sdk/pkg/txdecode"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportUnsignedPath string
+	signedOutPath      string
+	permissionID       int32
+	estimateOnly       bool
+	simulateOnly       bool
+)
+
+// prepareTransaction apply --permission-id to tx then print its --estimate,
+// --simulate its call or write it to the --export-unsigned file instead of
+// signing it, reporting whether it did
+func prepareTransaction(ctx context.Context, tx *api.TransactionExtention) (bool, error) {
+	if permissionID > 0 {
+		if err := conn.SetPermissionID(tx, permissionID); err != nil {
+			return false, err
+		}
+	}
+	if estimateOnly {
+		return true, printEstimate(ctx, tx.Transaction)
+	}
+	if simulateOnly {
+		return true, printSimulation(ctx, tx.Transaction)
+	}
+	if len(exportUnsignedPath) == 0 {
+		return false, nil
+	}
+	summary, err := txdecode.New(conn).Decode(ctx, tx.Transaction)
+	if err != nil {
+		return true, err
+	}
+	f, err := transaction.NewFile(tx.Transaction, summary)
+	if err != nil {
+		return true, err
+	}
+	if err := f.Write(exportUnsignedPath); err != nil {
+		return true, err
+	}
+	result := map[string]interface{}{
+		"txID":       f.TxID,
+		"expiration": summary.Expiration,
+		"file":       exportUnsignedPath,
+	}
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return true, nil
+}
+
+// printEstimate cost of tx to the signer, the contract owner by default
+func printEstimate(ctx context.Context, tx *core.Transaction) error {
+	sender := signerAddress.String()
+	if sender == "" {
+		var err error
+		if sender, err = contractOwner(tx); err != nil {
+			return err
+		}
+	}
+	estimate, err := conn.EstimateCost(ctx, tx, sender)
+	if err != nil {
+		return err
+	}
+	asJSON, _ := json.Marshal(estimate)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
+// printSimulation outcome of the smart contract call of tx
+func printSimulation(ctx context.Context, tx *core.Transaction) error {
+	simulation, err := conn.Simulate(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if noPrettyOutput {
+		fmt.Println(simulation)
+		return nil
+	}
+	result := map[string]interface{}{
+		"success":    simulation.Success,
+		"energyUsed": simulation.EnergyUsed,
+		"result":     common.BytesToHexString(simulation.Result),
+	}
+	if len(simulation.Message) > 0 {
+		result["message"] = simulation.Message
+	}
+	if simulation.Revert != nil {
+		result["revert"] = simulation.Revert
+	}
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
+// contractOwner owner address of the first contract of tx
+func contractOwner(tx *core.Transaction) (string, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return "", fmt.Errorf("transaction has no contract")
+	}
+	msg, err := txdecode.Unpack(contracts[0])
+	if err != nil {
+		return "", err
+	}
+	owned, ok := msg.(interface{ GetOwnerAddress() []byte })
+	if !ok || len(owned.GetOwnerAddress()) == 0 {
+		return "", fmt.Errorf("%s has no owner address", contracts[0].GetType())
+	}
+	return address.Address(owned.GetOwnerAddress()).String(), nil
+}
+
+func txSub() []*cobra.Command {
+	ctx := context.Background()
+
+	cmdSign := &cobra.Command{
+		Use:   "sign <FILE>",
+		Short: "sign a transaction file without network access",
+		Long: `Sign a transaction file exported with --export-unsigned. The signer
+defaults to the contract owner. The signature is appended, so keys of a
+multi-signature permission can sign the same file in turn. The signed file
+overwrites the input unless --out is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := transaction.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := f.Transaction()
+			if err != nil {
+				return err
+			}
+
+			// review what is signed from the raw data, not the file summary
+			summary, err := txdecode.New(nil).Decode(ctx, tx)
+			if err != nil {
+				return err
+			}
+			asJSON, _ := json.Marshal(summary)
+			fmt.Fprintln(os.Stderr, common.JSONPrettyFormat(string(asJSON)))
+
+			signerAddr := signerAddress.String()
+			if signerAddr == "" {
+				if signerAddr, err = contractOwner(tx); err != nil {
+					return err
+				}
+			}
+			signers, err := f.Signers()
+			if err != nil {
+				return err
+			}
+			for _, s := range signers {
+				if s.String() == signerAddr {
+					return fmt.Errorf("transaction %s already signed by %s", f.TxID, signerAddr)
+				}
+			}
+			offline := func(ctlr *transaction.Controller) {
+				if useLedgerWallet {
+					ctlr.Behavior.SigningImpl = transaction.Ledger
+					ctlr.Behavior.LedgerOptions = ledgerOptions
+				}
+			}
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				signerAcct, err := findAddress(signerAddr)
+				if err != nil {
+					return err
+				}
+				account := keystore.Account{Address: signerAcct.GetAddress()}
+				ctrlr = transaction.NewController(nil, nil, &account, tx, offline)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddr, passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(nil, ks, acct, tx, offline)
+			}
+			if err := ctrlr.Sign(); err != nil {
+				return err
+			}
+
+			signed, err := transaction.NewFile(ctrlr.Transaction(), f.Summary)
+			if err != nil {
+				return err
+			}
+			out := args[0]
+			if len(signedOutPath) > 0 {
+				out = signedOutPath
+			}
+			if err := signed.Write(out); err != nil {
+				return err
+			}
+
+			result := map[string]interface{}{
+				"txID":       signed.TxID,
+				"signer":     signerAddr,
+				"signatures": len(signed.Signatures),
+				"file":       out,
+			}
+			asJSON, _ = json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdSign.Flags().StringVar(&signedOutPath, "out", "", "write the signed transaction to this file")
+
+	cmdBroadcast := &cobra.Command{
+		Use:   "broadcast <FILE>",
+		Short: "broadcast a signed transaction file and wait for confirmation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := transaction.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := f.Transaction()
+			if err != nil {
+				return err
+			}
+			if len(tx.GetSignature()) == 0 {
+				return fmt.Errorf("transaction %s is not signed", f.TxID)
+			}
+
+			ctrlr := transaction.NewController(conn, nil, nil, tx, opts)
+			if err := ctrlr.Broadcast(ctx); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"] = f.TxID
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["message"] = string(ctrlr.Result.Message)
+			result["success"] = ctrlr.GetResultError() == nil
+			result["receipt"] = map[string]interface{}{
+				"fee":      ctrlr.Receipt.Fee,
+				"netFee":   ctrlr.Receipt.Receipt.NetFee,
+				"netUsage": ctrlr.Receipt.Receipt.NetUsage,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdMerge := &cobra.Command{
+		Use:   "merge <OUT_FILE> <FILE>...",
+		Short: "merge signatures collected separately on copies of a transaction file",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			merged, err := transaction.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			for _, path := range args[2:] {
+				f, err := transaction.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				if err := merged.Merge(f); err != nil {
+					return fmt.Errorf("%s: %v", path, err)
+				}
+			}
+			signers, err := merged.Signers()
+			if err != nil {
+				return err
+			}
+			if err := merged.Write(args[0]); err != nil {
+				return err
+			}
+			signerList := make([]string, len(signers))
+			for i, s := range signers {
+				signerList[i] = s.String()
+			}
+
+			result := map[string]interface{}{
+				"txID":    merged.TxID,
+				"signers": signerList,
+				"file":    args[0],
+			}
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdStatus := &cobra.Command{
+		Use:   "status <FILE>",
+		Short: "show signature weight collected against the permission threshold",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := transaction.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := f.Transaction()
+			if err != nil {
+				return err
+			}
+			weight, err := conn.GetTransactionSignWeight(ctx, tx)
+			if err != nil {
+				return err
+			}
+			approved, err := conn.GetTransactionApprovedList(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if noPrettyOutput {
+				fmt.Println(weight, approved)
+				return nil
+			}
+
+			signed := make(map[string]bool)
+			approvedList := make([]string, 0, len(approved.GetApprovedList()))
+			for _, a := range approved.GetApprovedList() {
+				signed[address.Address(a).String()] = true
+				approvedList = append(approvedList, address.Address(a).String())
+			}
+			perm := weight.GetPermission()
+			keys := make([]map[string]interface{}, 0, len(perm.GetKeys()))
+			for _, k := range perm.GetKeys() {
+				keyAddr := address.Address(k.Address).String()
+				keys = append(keys, map[string]interface{}{
+					"address": keyAddr,
+					"weight":  k.Weight,
+					"signed":  signed[keyAddr],
+				})
+			}
+
+			result := make(map[string]interface{})
+			result["txID"] = f.TxID
+			result["permission"] = map[string]interface{}{
+				"id":        f.PermissionID,
+				"name":      perm.GetPermissionName(),
+				"threshold": perm.GetThreshold(),
+				"keys":      keys,
+			}
+			result["currentWeight"] = weight.GetCurrentWeight()
+			result["approvedList"] = approvedList
+			result["result"] = weight.GetResult().GetCode().String()
+			result["ready"] = weight.GetResult().GetCode() == api.TransactionSignWeight_Result_ENOUGH_PERMISSION
+			if msg := weight.GetResult().GetMessage(); len(msg) > 0 {
+				result["message"] = msg
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdSign, cmdMerge, cmdStatus, cmdBroadcast}
+}
+
+func init() {
+	cmdTx := &cobra.Command{
+		Use:   "tx",
+		Short: "Offline and multi-signature transaction signing",
+		Long: `Sign transaction files exported with --export-unsigned on an offline
+machine, collect the signatures a multi-signature permission needs, then
+broadcast them from an online one.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdTx.AddCommand(txSub()...)
+	RootCmd.AddCommand(cmdTx)
+}
@@ -39,6 +39,42 @@ type Config struct {
 	NoPretty bool   `yaml:"noPretty"`
 	APIKey   string `yaml:"apiKey"`
 	WithTLS  bool   `yaml:"withTLS"`
+	// Network active profile, empty uses the settings above
+	Network  string              `yaml:"network,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile defines the settings of a named network
+type Profile struct {
+	Node           string `yaml:"node,omitempty" json:"node,omitempty"`
+	SolidityNode   string `yaml:"solidityNode,omitempty" json:"solidityNode,omitempty"`
+	WithTLS        *bool  `yaml:"withTLS,omitempty" json:"withTLS,omitempty"`
+	APIKey         string `yaml:"apiKey,omitempty" json:"apiKey,omitempty"`
+	Signer         string `yaml:"signer,omitempty" json:"signer,omitempty"`
+	KeystoreDir    string `yaml:"keystoreDir,omitempty" json:"keystoreDir,omitempty"`
+	FeeLimit       int64  `yaml:"feeLimit,omitempty" json:"feeLimit,omitempty"`
+	DeployFeeLimit int64  `yaml:"deployFeeLimit,omitempty" json:"deployFeeLimit,omitempty"`
+}
+
+// presetProfiles built-in public networks, config profiles of the same
+// name override their fields
+var presetProfiles = map[string]Profile{
+	"mainnet": {
+		Node:         "grpc.trongrid.io:50051",
+		SolidityNode: "grpc.trongrid.io:50052",
+	},
+	"shasta": {
+		Node:         "grpc.shasta.trongrid.io:50051",
+		SolidityNode: "grpc.shasta.trongrid.io:50052",
+	},
+	"nile": {
+		Node:         "grpc.nile.trongrid.io:50051",
+		SolidityNode: "grpc.nile.trongrid.io:50061",
+	},
+	"private": {
+		Node:         "127.0.0.1:50051",
+		SolidityNode: "127.0.0.1:50061",
+	},
 }
 
 // ReadConfig represents the current config read from local
@@ -0,0 +1,140 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errorSelector = Signature("Error(string)")
+	panicSelector = Signature("Panic(uint256)")
+)
+
+// panicReasons meaning of Solidity Panic(uint256) codes
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized internal function",
+}
+
+// Revert decoded revert data of a failed call
+type Revert struct {
+	// Name Error, Panic or the custom error, empty when not decoded
+	Name string `json:"name,omitempty"`
+	// Reason human readable revert reason
+	Reason string `json:"reason"`
+	// Code Panic code
+	Code *big.Int `json:"code,omitempty"`
+	// Args custom error arguments, addresses in base58
+	Args []interface{} `json:"args,omitempty"`
+	// Data raw revert data
+	Data []byte `json:"-"`
+}
+
+// String implements fmt.Stringer
+func (r *Revert) String() string {
+	return r.Reason
+}
+
+// PanicReason meaning of a Solidity panic code
+func PanicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic"
+}
+
+// DecodeRevert revert data of a call, custom errors are resolved from
+// contractABI which may be nil. It returns nil when data is empty.
+func DecodeRevert(data []byte, contractABI *core.SmartContract_ABI) *Revert {
+	if len(data) == 0 {
+		return nil
+	}
+	r := &Revert{Data: data}
+	if len(data) >= 4 {
+		selector, payload := data[:4], data[4:]
+		switch {
+		case bytes.Equal(selector, errorSelector):
+			if args, err := unpack(payload, "string"); err == nil {
+				r.Name = "Error"
+				r.Reason = args[0].(string)
+				return r
+			}
+		case bytes.Equal(selector, panicSelector):
+			if args, err := unpack(payload, "uint256"); err == nil {
+				r.Name = "Panic"
+				r.Code = args[0].(*big.Int)
+				r.Reason = fmt.Sprintf("panic 0x%02x: %s", r.Code, PanicReason(r.Code))
+				return r
+			}
+		default:
+			if decodeCustomError(r, selector, payload, contractABI) {
+				return r
+			}
+		}
+	}
+	r.Reason = "unknown revert data " + common.BytesToHexString(data)
+	return r
+}
+
+// decodeCustomError resolve a Solidity custom error from the contract ABI
+func decodeCustomError(r *Revert, selector, payload []byte, contractABI *core.SmartContract_ABI) bool {
+	for _, entry := range contractABI.GetEntrys() {
+		if entry.Type != core.SmartContract_ABI_Entry_Error {
+			continue
+		}
+		types := make([]string, len(entry.Inputs))
+		for i, in := range entry.Inputs {
+			types[i] = in.Type
+		}
+		if !bytes.Equal(Signature(entry.Name+"("+strings.Join(types, ",")+")"), selector) {
+			continue
+		}
+		args, err := unpack(payload, types...)
+		if err != nil {
+			return false
+		}
+		r.Name = entry.Name
+		r.Args = make([]interface{}, len(args))
+		values := make([]string, len(args))
+		for i, arg := range args {
+			if a, ok := arg.(eCommon.Address); ok {
+				arg = address.Address(append([]byte{address.TronBytePrefix}, a.Bytes()...)).String()
+			}
+			r.Args[i] = arg
+			values[i] = fmt.Sprint(arg)
+		}
+		r.Reason = fmt.Sprintf("%s(%s)", entry.Name, strings.Join(values, ", "))
+		return true
+	}
+	return false
+}
+
+func unpack(payload []byte, types ...string) ([]interface{}, error) {
+	arguments := eABI.Arguments{}
+	for _, t := range types {
+		ty, err := eABI.NewType(t, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %s: %+v", t, err)
+		}
+		arguments = append(arguments, eABI.Argument{Type: ty})
+	}
+	return arguments.UnpackValues(payload)
+}
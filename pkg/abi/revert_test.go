@@ -0,0 +1,49 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	require.Nil(t, DecodeRevert(nil, nil))
+
+	data, err := Pack("Error(string)", []Param{{"string": "insufficient allowance"}})
+	require.Nil(t, err)
+	r := DecodeRevert(data, nil)
+	require.Equal(t, "Error", r.Name)
+	require.Equal(t, "insufficient allowance", r.String())
+
+	data, err = Pack("Panic(uint256)", []Param{{"uint256": "17"}})
+	require.Nil(t, err)
+	r = DecodeRevert(data, nil)
+	require.Equal(t, "Panic", r.Name)
+	require.Equal(t, big.NewInt(0x11), r.Code)
+	require.Equal(t, "panic 0x11: arithmetic overflow or underflow", r.Reason)
+
+	contractABI := &core.SmartContract_ABI{Entrys: []*core.SmartContract_ABI_Entry{
+		{Type: core.SmartContract_ABI_Entry_Function, Name: "transfer"},
+		{Type: core.SmartContract_ABI_Entry_Error, Name: "InsufficientBalance", Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Name: "account", Type: "address"},
+			{Name: "needed", Type: "uint256"},
+		}},
+	}}
+	data, err = Pack("InsufficientBalance(address,uint256)", []Param{
+		{"address": "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"},
+		{"uint256": "250000"},
+	})
+	require.Nil(t, err)
+	r = DecodeRevert(data, contractABI)
+	require.Equal(t, "InsufficientBalance", r.Name)
+	require.Equal(t, []interface{}{"TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM", big.NewInt(250000)}, r.Args)
+	require.Equal(t, "InsufficientBalance(TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM, 250000)", r.Reason)
+
+	// without the ABI the custom error stays raw
+	r = DecodeRevert(data, nil)
+	require.Empty(t, r.Name)
+	require.Equal(t, data, r.Data)
+	require.Contains(t, r.Reason, "unknown revert data")
+}
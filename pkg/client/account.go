@@ -81,8 +81,8 @@ func (g *Client) CreateAccount(ctx context.Context, from, addr string) (*api.Tra
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -103,8 +103,8 @@ func (g *Client) UpdateAccount(ctx context.Context, from, accountName string) (*
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -308,8 +308,8 @@ func (g *Client) WithdrawBalance(ctx context.Context, from string) (*api.Transac
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -446,8 +446,8 @@ func (g *Client) UpdateAccountPermission(ctx context.Context, from string, owner
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
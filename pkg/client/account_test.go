@@ -2,41 +2,36 @@ package client_test
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"testing"
 
 	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
 	"github.com/elleqt/gotron-sdk/pkg/proto/core"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/grpc"
 )
 
 var (
-	conn                              *client.Client
-	apiKey                            = "622ec85e-7406-431d-9caf-0a19501469a4"
-	tronAddress                       = "grpc.nile.trongrid.io:50051"
 	accountAddress                    = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
 	accountAddressWitness             = "TGj1Ej1qRzL9feLTLhjwgxXF4Ct6GTWg2U"
 	testnetNileAddressExample         = "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"
 	testnetNileAddressDelegateExample = "TZ4UXDV5ZhNW7fb2AMSbgfAEZ7hWsnYS2g"
+	usdt                              = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
 )
 
-func TestMain(m *testing.M) {
-	opts := make([]grpc.DialOption, 0)
-	opts = append(opts, grpc.WithInsecure())
-
-	conn = client.New(tronAddress)
-
-	if err := conn.Start(opts...); err != nil {
-		_ = fmt.Errorf("Error connecting GRPC Client: %v", err)
-	}
-
-	exitVal := m.Run()
-	os.Exit(exitVal)
+// newTestNode in-memory node and a client connected to it, both closed at
+// the end of the test
+func newTestNode(t *testing.T) (*clienttest.Node, *client.Client) {
+	node := clienttest.NewNode()
+	t.Cleanup(node.Close)
+	c, err := node.Client()
+	require.Nil(t, err)
+	t.Cleanup(c.Stop)
+	return node, c
 }
 
 func TestGetAccountDetailed(t *testing.T) {
+	node, conn := newTestNode(t)
+	node.Fund(accountAddress, 5_000_000)
 	acc, err := conn.GetAccountDetailed(context.Background(), accountAddress)
 	require.Nil(t, err)
 	require.NotNil(t, acc.Allowance)
@@ -51,6 +46,8 @@ func TestGetAccountDetailed(t *testing.T) {
 }
 
 func TestGetAccountDetailedV2(t *testing.T) {
+	node, conn := newTestNode(t)
+	node.Fund(testnetNileAddressExample, 5_000_000)
 	acc, err := conn.GetAccountDetailed(context.Background(), testnetNileAddressExample)
 
 	require.Nil(t, err)
@@ -64,6 +61,7 @@ func TestGetAccountDetailedV2(t *testing.T) {
 
 func TestFreezeV2(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	freezeTx, err := conn.FreezeBalanceV2(context.Background(), testnetNileAddressExample, core.ResourceCode_BANDWIDTH, 1000000)
 
 	require.Nil(t, err)
@@ -73,6 +71,7 @@ func TestFreezeV2(t *testing.T) {
 
 func TestUnfreezeV2(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	unfreezeTx, err := conn.UnfreezeBalanceV2(context.Background(), testnetNileAddressExample, core.ResourceCode_BANDWIDTH, 1000000)
 
 	require.Nil(t, err)
@@ -82,6 +81,7 @@ func TestUnfreezeV2(t *testing.T) {
 
 func TestDelegate(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	tx, err := conn.DelegateResource(context.Background(), testnetNileAddressExample, testnetNileAddressDelegateExample, core.ResourceCode_BANDWIDTH, 1000000, false, 10000)
 
 	require.Nil(t, err)
@@ -90,6 +90,7 @@ func TestDelegate(t *testing.T) {
 
 func TestUndelegate(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	tx, err := conn.UnDelegateResource(context.Background(), testnetNileAddressExample, testnetNileAddressDelegateExample, core.ResourceCode_BANDWIDTH, 1000000, false)
 
 	require.Nil(t, err)
@@ -98,6 +99,7 @@ func TestUndelegate(t *testing.T) {
 
 func TestDelegateMaxSize(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	tx, err := conn.GetCanDelegatedMaxSize(context.Background(), testnetNileAddressExample, int32(core.ResourceCode_BANDWIDTH.Number()))
 
 	require.Nil(t, err)
@@ -106,6 +108,7 @@ func TestDelegateMaxSize(t *testing.T) {
 
 func TestUnfreezeLeftCount(t *testing.T) {
 	t.Skip() // Only in testnet nile
+	_, conn := newTestNode(t)
 	tx, err := conn.GetAvailableUnfreezeCount(context.Background(), testnetNileAddressExample)
 
 	require.Nil(t, err)
@@ -131,8 +131,8 @@ func (g *Client) AssetIssue(ctx context.Context, from, name, description, abbr,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -159,8 +159,8 @@ func (g *Client) UpdateAssetIssue(ctx context.Context, from, description, urlStr
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -187,8 +187,8 @@ func (g *Client) TransferAsset(ctx context.Context, from, toAddress,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -215,8 +215,8 @@ func (g *Client) ParticipateAssetIssue(ctx context.Context, from, issuerAddress,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -237,8 +237,8 @@ func (g *Client) UnfreezeAsset(ctx context.Context, from string) (*api.Transacti
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -38,8 +38,8 @@ func (g *Client) FreezeBalance(ctx context.Context, from, delegateTo string,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -64,8 +64,8 @@ func (g *Client) FreezeBalanceV2(ctx context.Context, from string,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -94,8 +94,8 @@ func (g *Client) UnfreezeBalance(ctx context.Context, from, delegateTo string, r
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -119,8 +119,8 @@ func (g *Client) UnfreezeBalanceV2(ctx context.Context, from string, resource co
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -66,6 +66,10 @@ func (g *Client) GetBlockByID(ctx context.Context, id string) (*core.Block, erro
 	return g.Client.GetBlockById(ctx, blockID, maxSizeOption)
 }
 
+// maxBlockLimit blocks java-tron returns from one GetBlockByLimitNext2
+// call, it answers larger ranges with an empty list
+const maxBlockLimit = 100
+
 // GetBlockByLimitNext return list of block start/end
 func (g *Client) GetBlockByLimitNext(ctx context.Context, start, end int64) (*api.BlockListExtention, error) {
 	blockLimit := new(api.BlockLimit)
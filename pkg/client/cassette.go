@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CassetteMode select whether a cassette captures or serves calls
+type CassetteMode int
+
+const (
+	// CassetteReplay serve calls from the cassette file, never reaching a node
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord forward calls to the node and capture them
+	CassetteRecord
+)
+
+// ErrCassetteMiss is returned in replay mode for calls without a recorded
+// interaction
+var ErrCassetteMiss = errors.New("cassette: no recorded interaction")
+
+// Interaction one recorded unary call, messages in protobuf JSON
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	// Code and Message of the gRPC status for failed calls
+	Code    codes.Code `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette records unary calls of a client to a file and replays them.
+// Replay matches calls by method and request; identical calls are served
+// in recorded order, the last one repeating once all were played.
+type Cassette struct {
+	// Match compare a recorded request with a live one of the same method,
+	// proto.Equal when nil. Use it to ignore fields such as timestamps.
+	Match func(method string, recorded, req proto.Message) bool
+
+	path         string
+	mode         CassetteMode
+	mu           sync.Mutex
+	interactions []*Interaction
+	played       []bool
+}
+
+// NewCassette open a cassette file. Replay loads the file, which must
+// exist; record starts empty and writes the file on Save.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	if mode == CassetteRecord {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %v", err)
+	}
+	var f cassetteFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("cassette %s: %v", path, err)
+	}
+	c.interactions = f.Interactions
+	c.played = make([]bool, len(f.Interactions))
+	return c, nil
+}
+
+// Mode of the cassette
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Save write recorded interactions to the cassette file, no-op in replay
+func (c *Cassette) Save() error {
+	if c.mode != CassetteRecord {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(&cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// Unplayed return recorded interactions replay has not served yet
+func (c *Cassette) Unplayed() []*Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unplayed []*Interaction
+	for i, played := range c.played {
+		if !played {
+			unplayed = append(unplayed, c.interactions[i])
+		}
+	}
+	return unplayed
+}
+
+// SetCassette record calls to, or replay them from, cassette; nil disables
+func (g *Client) SetCassette(cassette *Cassette) {
+	g.cassette = cassette
+}
+
+// cassetteUnaryInterceptor record or replay calls, outside retries so a
+// recorded call is the one the caller saw
+func (g *Client) cassetteUnaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c := g.cassette
+	if c == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	in, ok := req.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cassette: %s request is not a protobuf message", method)
+	}
+	out, ok := reply.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cassette: %s reply is not a protobuf message", method)
+	}
+	if c.mode == CassetteRecord {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if recErr := c.record(method, in, out, err); recErr != nil {
+			return recErr
+		}
+		return err
+	}
+	return c.replay(method, in, out)
+}
+
+func (c *Cassette) record(method string, req, reply proto.Message, callErr error) error {
+	it := &Interaction{Method: method}
+	var err error
+	if it.Request, err = protojson.Marshal(req); err != nil {
+		return fmt.Errorf("cassette: %s request: %v", method, err)
+	}
+	if callErr != nil {
+		s := status.Convert(callErr)
+		it.Code, it.Message = s.Code(), s.Message()
+	} else if it.Response, err = protojson.Marshal(reply); err != nil {
+		return fmt.Errorf("cassette: %s response: %v", method, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, it)
+	c.played = append(c.played, true)
+	return nil
+}
+
+func (c *Cassette) replay(method string, req, reply proto.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	match := -1
+	for i, it := range c.interactions {
+		if it.Method != method {
+			continue
+		}
+		recorded := req.ProtoReflect().New().Interface()
+		if err := protojson.Unmarshal(it.Request, recorded); err != nil {
+			return fmt.Errorf("cassette: %s request: %v", method, err)
+		}
+		if !c.match(method, recorded, req) {
+			continue
+		}
+		match = i
+		if !c.played[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return fmt.Errorf("%w for %s %s", ErrCassetteMiss, method, protojson.Format(req))
+	}
+	c.played[match] = true
+	it := c.interactions[match]
+	if it.Code != codes.OK {
+		return status.Error(it.Code, it.Message)
+	}
+	if err := protojson.Unmarshal(it.Response, reply); err != nil {
+		return fmt.Errorf("cassette: %s response: %v", method, err)
+	}
+	return nil
+}
+
+func (c *Cassette) match(method string, recorded, req proto.Message) bool {
+	if c.Match != nil {
+		return c.Match(method, recorded, req)
+	}
+	return proto.Equal(recorded, req)
+}
@@ -0,0 +1,57 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	node.Fund(accountAddress, 5_000_000)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := client.NewCassette(path, client.CassetteRecord)
+	require.Nil(t, err)
+	c := client.New(clienttest.Address)
+	c.SetCassette(recorder)
+	require.Nil(t, c.Start(node.DialOptions()...))
+	acc, err := c.GetAccount(context.Background(), accountAddress)
+	require.Nil(t, err)
+	require.Equal(t, int64(5_000_000), acc.Balance)
+	_, err = c.Transfer(context.Background(), accountAddress, testnetNileAddressExample, 9_000_000)
+	require.EqualError(t, err, "balance is not sufficient")
+	c.Stop()
+	require.Nil(t, recorder.Save())
+
+	// replay never reaches the address
+	player, err := client.NewCassette(path, client.CassetteReplay)
+	require.Nil(t, err)
+	c = client.New("passthrough:///unreachable")
+	c.SetCassette(player)
+	require.Nil(t, c.Start(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	defer c.Stop()
+
+	acc, err = c.GetAccount(context.Background(), accountAddress)
+	require.Nil(t, err)
+	require.Equal(t, int64(5_000_000), acc.Balance)
+	_, err = c.Transfer(context.Background(), accountAddress, testnetNileAddressExample, 9_000_000)
+	require.EqualError(t, err, "balance is not sufficient")
+	require.Empty(t, player.Unplayed())
+
+	_, err = c.GetAccount(context.Background(), testnetNileAddressExample)
+	require.True(t, errors.Is(err, client.ErrCassetteMiss), err)
+}
+
+func TestCassetteMissingFile(t *testing.T) {
+	_, err := client.NewCassette(filepath.Join(t.TempDir(), "missing.json"), client.CassetteReplay)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+)
+
+// DefaultChainParametersTTL how long ChainParameters is cached. Parameters
+// only change at maintenance, every 6 hours on mainnet.
+const DefaultChainParametersTTL = 10 * time.Minute
+
+// ChainParameters network parameters, the dynamic properties committee
+// proposals change. Fees are in SUN. Keys the node reports but which have
+// no field are kept in Raw.
+type ChainParameters struct {
+	MaintenanceTimeInterval             int64 `param:"getMaintenanceTimeInterval" json:"maintenanceTimeInterval"`
+	AccountUpgradeCost                  int64 `param:"getAccountUpgradeCost" json:"accountUpgradeCost"`
+	CreateAccountFee                    int64 `param:"getCreateAccountFee" json:"createAccountFee"`
+	TransactionFee                      int64 `param:"getTransactionFee" json:"transactionFee"`
+	AssetIssueFee                       int64 `param:"getAssetIssueFee" json:"assetIssueFee"`
+	WitnessPayPerBlock                  int64 `param:"getWitnessPayPerBlock" json:"witnessPayPerBlock"`
+	WitnessStandbyAllowance             int64 `param:"getWitnessStandbyAllowance" json:"witnessStandbyAllowance"`
+	CreateNewAccountFeeInSystemContract int64 `param:"getCreateNewAccountFeeInSystemContract" json:"createNewAccountFeeInSystemContract"`
+	CreateNewAccountBandwidthRate       int64 `param:"getCreateNewAccountBandwidthRate" json:"createNewAccountBandwidthRate"`
+	EnergyFee                           int64 `param:"getEnergyFee" json:"energyFee"`
+	ExchangeCreateFee                   int64 `param:"getExchangeCreateFee" json:"exchangeCreateFee"`
+	MaxCPUTimeOfOneTx                   int64 `param:"getMaxCpuTimeOfOneTx" json:"maxCpuTimeOfOneTx"`
+	TotalEnergyLimit                    int64 `param:"getTotalEnergyLimit" json:"totalEnergyLimit"`
+	TotalEnergyCurrentLimit             int64 `param:"getTotalEnergyCurrentLimit" json:"totalEnergyCurrentLimit"`
+	FreeNetLimit                        int64 `param:"getFreeNetLimit" json:"freeNetLimit"`
+	TotalNetLimit                       int64 `param:"getTotalNetLimit" json:"totalNetLimit"`
+	UpdateAccountPermissionFee          int64 `param:"getUpdateAccountPermissionFee" json:"updateAccountPermissionFee"`
+	MultiSignFee                        int64 `param:"getMultiSignFee" json:"multiSignFee"`
+	MemoFee                             int64 `param:"getMemoFee" json:"memoFee"`
+	MaxFeeLimit                         int64 `param:"getMaxFeeLimit" json:"maxFeeLimit"`
+	MarketSellFee                       int64 `param:"getMarketSellFee" json:"marketSellFee"`
+	MarketCancelFee                     int64 `param:"getMarketCancelFee" json:"marketCancelFee"`
+	UnfreezeDelayDays                   int64 `param:"getUnfreezeDelayDays" json:"unfreezeDelayDays"`
+	MaxDelegateLockPeriod               int64 `param:"getMaxDelegateLockPeriod" json:"maxDelegateLockPeriod"`
+	DynamicEnergyThreshold              int64 `param:"getDynamicEnergyThreshold" json:"dynamicEnergyThreshold"`
+	DynamicEnergyIncreaseFactor         int64 `param:"getDynamicEnergyIncreaseFactor" json:"dynamicEnergyIncreaseFactor"`
+	DynamicEnergyMaxFactor              int64 `param:"getDynamicEnergyMaxFactor" json:"dynamicEnergyMaxFactor"`
+
+	AllowCreationOfContracts      bool `param:"getAllowCreationOfContracts" json:"allowCreationOfContracts"`
+	AllowUpdateAccountName        bool `param:"getAllowUpdateAccountName" json:"allowUpdateAccountName"`
+	AllowSameTokenName            bool `param:"getAllowSameTokenName" json:"allowSameTokenName"`
+	AllowDelegateResource         bool `param:"getAllowDelegateResource" json:"allowDelegateResource"`
+	AllowMultiSign                bool `param:"getAllowMultiSign" json:"allowMultiSign"`
+	AllowAdaptiveEnergy           bool `param:"getAllowAdaptiveEnergy" json:"allowAdaptiveEnergy"`
+	AllowTvmTransferTrc10         bool `param:"getAllowTvmTransferTrc10" json:"allowTvmTransferTrc10"`
+	AllowTvmConstantinople        bool `param:"getAllowTvmConstantinople" json:"allowTvmConstantinople"`
+	AllowTvmSolidity059           bool `param:"getAllowTvmSolidity059" json:"allowTvmSolidity059"`
+	AllowTvmIstanbul              bool `param:"getAllowTvmIstanbul" json:"allowTvmIstanbul"`
+	AllowTvmFreeze                bool `param:"getAllowTvmFreeze" json:"allowTvmFreeze"`
+	AllowTvmVote                  bool `param:"getAllowTvmVote" json:"allowTvmVote"`
+	AllowTvmLondon                bool `param:"getAllowTvmLondon" json:"allowTvmLondon"`
+	AllowTvmCompatibleEvm         bool `param:"getAllowTvmCompatibleEvm" json:"allowTvmCompatibleEvm"`
+	AllowTvmShangHai              bool `param:"getAllowTvmShangHai" json:"allowTvmShangHai"`
+	AllowTvmCancun                bool `param:"getAllowTvmCancun" json:"allowTvmCancun"`
+	AllowShieldedTRC20Transaction bool `param:"getAllowShieldedTRC20Transaction" json:"allowShieldedTRC20Transaction"`
+	AllowMarketTransaction        bool `param:"getAllowMarketTransaction" json:"allowMarketTransaction"`
+	AllowPBFT                     bool `param:"getAllowPBFT" json:"allowPBFT"`
+	AllowTransactionFeePool       bool `param:"getAllowTransactionFeePool" json:"allowTransactionFeePool"`
+	AllowNewResourceModel         bool `param:"getAllowNewResourceModel" json:"allowNewResourceModel"`
+	AllowAccountStateRoot         bool `param:"getAllowAccountStateRoot" json:"allowAccountStateRoot"`
+	AllowNewReward                bool `param:"getAllowNewReward" json:"allowNewReward"`
+	AllowDelegateOptimization     bool `param:"getAllowDelegateOptimization" json:"allowDelegateOptimization"`
+	AllowDynamicEnergy            bool `param:"getAllowDynamicEnergy" json:"allowDynamicEnergy"`
+	AllowCancelAllUnfreezeV2      bool `param:"getAllowCancelAllUnfreezeV2" json:"allowCancelAllUnfreezeV2"`
+	ForbidTransferToContract      bool `param:"getForbidTransferToContract" json:"forbidTransferToContract"`
+
+	// Raw every key reported by the node with its value
+	Raw map[string]int64 `json:"raw"`
+}
+
+// chainParametersCache last fetched parameters
+type chainParametersCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	params  *ChainParameters
+	fetched time.Time
+}
+
+// chainParametersCache of the client, created on first use so zero value
+// clients get one too
+func (g *Client) chainParametersCache() *chainParametersCache {
+	if c, ok := g.chainParams.Load().(*chainParametersCache); ok {
+		return c
+	}
+	g.chainParams.CompareAndSwap(nil, new(chainParametersCache))
+	return g.chainParams.Load().(*chainParametersCache)
+}
+
+// SetChainParametersTTL set how long ChainParameters is cached,
+// DefaultChainParametersTTL by default. Zero or negative disables caching.
+func (g *Client) SetChainParametersTTL(ttl time.Duration) {
+	c := g.chainParametersCache()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ttl <= 0 {
+		ttl = -1
+	}
+	c.ttl = ttl
+	c.params = nil
+}
+
+// ChainParameters return the network parameters, cached for the TTL set by
+// SetChainParametersTTL
+func (g *Client) ChainParameters(ctx context.Context) (*ChainParameters, error) {
+	c := g.chainParametersCache()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ttl := c.ttl
+	if ttl == 0 {
+		ttl = DefaultChainParametersTTL
+	}
+	if c.params != nil && time.Since(c.fetched) < ttl {
+		return c.params.copy(), nil
+	}
+
+	list, err := g.Client.GetChainParameters(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	if len(list.GetChainParameter()) == 0 {
+		return nil, fmt.Errorf("chain parameters not found")
+	}
+	raw := make(map[string]int64, len(list.GetChainParameter()))
+	for _, p := range list.GetChainParameter() {
+		raw[p.GetKey()] = p.GetValue()
+	}
+	params := NewChainParameters(raw)
+	if ttl > 0 {
+		c.params, c.fetched = params, time.Now()
+	}
+	return params.copy(), nil
+}
+
+// DynamicProperties return the node dynamic properties, served by the
+// Database API. They change every block so they are not cached.
+func (g *Client) DynamicProperties(ctx context.Context) (*core.DynamicProperties, error) {
+	if g.Database == nil {
+		return nil, fmt.Errorf("database API not connected")
+	}
+	return g.Database.GetDynamicProperties(ctx, new(api.EmptyMessage))
+}
+
+// NewChainParameters type the parameters of a raw key map, as returned by
+// GetChainParameters
+func NewChainParameters(raw map[string]int64) *ChainParameters {
+	params := &ChainParameters{Raw: raw}
+	v := reflect.ValueOf(params).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		value, ok := raw[t.Field(i).Tag.Get("param")]
+		if !ok {
+			continue
+		}
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Int64:
+			f.SetInt(value)
+		case reflect.Bool:
+			f.SetBool(value != 0)
+		}
+	}
+	return params
+}
+
+func (p *ChainParameters) copy() *ChainParameters {
+	c := *p
+	c.Raw = make(map[string]int64, len(p.Raw))
+	for k, v := range p.Raw {
+		c.Raw[k] = v
+	}
+	return &c
+}
@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// countCalls dial option counting unary calls per method
+func countCalls(calls map[string]int) grpc.DialOption {
+	return grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		calls[method]++
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+func TestChainParameters(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	node.ChainParameters["getFutureProposal"] = 7
+	calls := make(map[string]int)
+	c, err := node.Client(countCalls(calls))
+	require.Nil(t, err)
+	defer c.Stop()
+
+	params, err := c.ChainParameters(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(420), params.EnergyFee)
+	require.Equal(t, int64(1_000_000), params.MemoFee)
+	require.Equal(t, int64(600), params.FreeNetLimit)
+	require.True(t, params.AllowMultiSign)
+	require.False(t, params.AllowTvmCancun)
+	require.Equal(t, int64(7), params.Raw["getFutureProposal"])
+
+	params.Raw["getEnergyFee"] = 1
+	params, err = c.ChainParameters(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(420), params.Raw["getEnergyFee"])
+	require.Equal(t, 1, calls["/protocol.Wallet/GetChainParameters"])
+
+	c.SetChainParametersTTL(time.Nanosecond)
+	node.ChainParameters["getEnergyFee"] = 210
+	time.Sleep(time.Millisecond)
+	params, err = c.ChainParameters(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(210), params.EnergyFee)
+	require.Equal(t, 2, calls["/protocol.Wallet/GetChainParameters"])
+}
+
+func TestChainParametersZeroClient(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	started, err := node.Client()
+	require.Nil(t, err)
+	defer started.Stop()
+
+	c := &client.Client{Client: started.Client}
+	c.SetChainParametersTTL(time.Minute)
+	params, err := c.ChainParameters(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(420), params.EnergyFee)
+}
+
+func TestDynamicProperties(t *testing.T) {
+	node := clienttest.NewNode(clienttest.WithManualBlocks())
+	defer node.Close()
+	node.SolidityLag = 2
+	for i := 0; i < 5; i++ {
+		node.ProduceBlock()
+	}
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	props, err := c.DynamicProperties(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(3), props.LastSolidityBlockNum)
+
+	_, err = (&client.Client{}).DynamicProperties(context.Background())
+	require.NotNil(t, err)
+}
+
+func TestNewChainParameters(t *testing.T) {
+	params := client.NewChainParameters(map[string]int64{
+		"getTransactionFee":   1000,
+		"getAllowTvmShangHai": 1,
+	})
+	require.Equal(t, int64(1000), params.TransactionFee)
+	require.True(t, params.AllowTvmShangHai)
+	require.Zero(t, params.EnergyFee)
+}
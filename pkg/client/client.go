@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/elleqt/gotron-sdk/pkg/proto/api"
 	"google.golang.org/grpc"
@@ -9,10 +10,22 @@ import (
 
 // Client controller structure
 type Client struct {
-	Address string
-	Conn    *grpc.ClientConn
-	Client  api.WalletClient
-	opts    []grpc.DialOption
+	Address         string
+	Conn            *grpc.ClientConn
+	Client          api.WalletClient
+	Database        api.DatabaseClient
+	SolidityAddress string
+	SolidityConn    *grpc.ClientConn
+	Solidity        api.WalletSolidityClient
+	opts            []grpc.DialOption
+	apiKey          string
+	pool            *Pool
+	retry           *RetryPolicy
+	limiter         *RateLimiter
+	observability   *Observability
+	cassette        *Cassette
+	// chainParams *chainParametersCache, see chainParametersCache
+	chainParams atomic.Value
 }
 
 // New create grpc controller
@@ -23,37 +36,82 @@ func New(address string) *Client {
 	return client
 }
 
-// Start initiate grpc  connection
+// Start initiate grpc  connection. Addresses starting with http:// or
+// https:// use the node HTTP API instead of gRPC.
 func (g *Client) Start(opts ...grpc.DialOption) error {
 	var err error
+	g.opts = opts
+	g.observability = observabilityFrom(opts)
+	if g.pool != nil {
+		if err = g.pool.start(g.dialOptions()...); err != nil {
+			return err
+		}
+		g.Client = api.NewWalletClient(g.intercept(g.pool))
+		g.Database = api.NewDatabaseClient(g.intercept(g.pool))
+		return g.startSolidity()
+	}
 	if len(g.Address) == 0 {
 		g.Address = "grpc.trongrid.io:50051"
 	}
-	g.opts = opts
-	g.Conn, err = grpc.NewClient(g.Address, opts...)
+	if IsHTTPAddress(g.Address) {
+		conn := g.intercept(newHTTPConn(g.Address), g.apiKeyUnaryInterceptor, g.observeUnaryInterceptor(g.Address))
+		g.Client = api.NewWalletClient(conn)
+		// the HTTP API has no Database service, calls fail as unimplemented
+		g.Database = api.NewDatabaseClient(conn)
+		return g.startSolidity()
+	}
+	g.Conn, err = grpc.NewClient(g.Address, g.dialOptions()...)
 
 	if err != nil {
 		return fmt.Errorf("connecting GRPC Client: %v", err)
 	}
-	g.Client = api.NewWalletClient(g.Conn)
+	g.Client = api.NewWalletClient(g.intercept(g.Conn))
+	g.Database = api.NewDatabaseClient(g.intercept(g.Conn))
+	return g.startSolidity()
+}
+
+// SetAPIKey sets the TronGrid API key sent on every call
+func (g *Client) SetAPIKey(apiKey string) error {
+	g.apiKey = apiKey
 	return nil
 }
 
+// dialOptions return caller options plus node level interceptors
+func (g *Client) dialOptions() []grpc.DialOption {
+	opts := make([]grpc.DialOption, 0, len(g.opts)+2)
+	opts = append(opts, g.opts...)
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(g.apiKeyUnaryInterceptor, g.observeUnaryInterceptor("")),
+		grpc.WithChainStreamInterceptor(g.apiKeyStreamInterceptor),
+	)
+	return opts
+}
+
 // Stop GRPC Connection
 func (g *Client) Stop() {
+	if g.pool != nil {
+		g.pool.close()
+	}
 	if g.Conn != nil {
 		g.Conn.Close()
 	}
+	if g.SolidityConn != nil {
+		g.SolidityConn.Close()
+	}
 }
 
-// Reconnect GRPC
+// Reconnect GRPC, url is ignored when backed by a pool
 func (g *Client) Reconnect(url string) error {
 	g.Stop()
-	if len(url) > 0 {
+	if len(url) > 0 && g.pool == nil {
 		g.Address = url
 	}
-	g.Start(g.opts...)
-	return nil
+	return g.Start(g.opts...)
+}
+
+// Pool return node pool backing the client, nil for single node
+func (g *Client) Pool() *Pool {
+	return g.pool
 }
 
 // GetMessageBytes return grpc message from bytes
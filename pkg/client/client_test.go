@@ -4,26 +4,38 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"math/big"
+	"net"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/proto"
 )
 
 func TestTRC20(t *testing.T) {
-	c := client.New("")
-	err := c.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	node, c := newTestNode(t)
+	for token, decimals := range map[string]int64{"TN7EWmuVWrdehLwKGnU2rk42GWodbAXGUM": 0, usdt: 6} {
+		word := common.LeftPadBytes(big.NewInt(decimals).Bytes(), 32)
+		node.HandleCall(token, "decimals()", func(*core.TriggerSmartContract) ([]byte, error) {
+			return word, nil
+		})
+	}
 
 	value, err := c.TRC20GetDecimals(context.Background(), "TN7EWmuVWrdehLwKGnU2rk42GWodbAXGUM")
 	require.Nil(t, err)
 	require.Equal(t, value.Int64(), int64(0))
 
-	value, err = c.TRC20GetDecimals(context.Background(), "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	value, err = c.TRC20GetDecimals(context.Background(), usdt)
 	require.Nil(t, err)
 	require.Equal(t, value.Int64(), int64(6))
 }
@@ -35,9 +47,7 @@ func TestSend(t *testing.T) {
 
 	privateKeyBytes, _ := hex.DecodeString("ABCD")
 
-	c := client.New("")
-	err := c.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	_, c := newTestNode(t)
 	tx, err := c.Transfer(context.Background(), fromAddress, toAddress, 1000)
 	require.Nil(t, err)
 
@@ -58,3 +68,38 @@ func TestSend(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, result)
 }
+
+type apiKeyServer struct {
+	api.UnimplementedWalletServer
+	keys []string
+}
+
+func (s *apiKeyServer) GetNowBlock2(ctx context.Context, _ *api.EmptyMessage) (*api.BlockExtention, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.keys = append(s.keys, md.Get(client.APIKeyHeader)...)
+	return &api.BlockExtention{}, nil
+}
+
+func TestAPIKey(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	wallet := &apiKeyServer{}
+	api.RegisterWalletServer(srv, wallet)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	c := client.New("passthrough:///bufnet")
+	require.Nil(t, c.SetAPIKey("my-key"))
+	err := c.Start(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	require.Nil(t, err)
+	defer c.Stop()
+
+	_, err = c.GetNowBlock(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, []string{"my-key"}, wallet.keys)
+}
@@ -0,0 +1,21 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+)
+
+// database serves the Database API of a node
+type database struct {
+	api.UnimplementedDatabaseServer
+	n *Node
+}
+
+// GetDynamicProperties implements api.DatabaseServer
+func (d *database) GetDynamicProperties(_ context.Context, _ *api.EmptyMessage) (*core.DynamicProperties, error) {
+	d.n.mu.Lock()
+	defer d.n.mu.Unlock()
+	return &core.DynamicProperties{LastSolidityBlockNum: d.n.solidified()}, nil
+}
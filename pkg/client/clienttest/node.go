@@ -0,0 +1,430 @@
+// Package clienttest provides an in-memory TRON node for tests.
+//
+// A Node serves the wallet gRPC API over an in-process listener and keeps
+// accounts, TRX and TRC10 balances, blocks and transaction receipts in
+// memory, so client.Client and transaction.Controller can be exercised end to
+// end without network access:
+//
+//	node := clienttest.NewNode()
+//	defer node.Close()
+//	node.Fund(owner, 100_000_000)
+//	c, err := node.Client()
+//
+// Blocks are also served through the solidity API on the same Address,
+// SolidityLag blocks behind the head. Signatures are weighed against the
+// account permissions, set with SetPermissions for multi-signature tests.
+package clienttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Address target of every node, resolved by the node dialer
+	Address = "passthrough:///clienttest"
+	// BlockInterval time between two produced blocks
+	BlockInterval = 3 * time.Second
+	// Expiration validity window of built transactions
+	Expiration = 60 * time.Second
+
+	firstAssetID = 1000001
+	bufSize      = 1024 * 1024
+)
+
+// CallHandler executes a smart contract call and returns its output.
+// Returning a *Revert reverts the call with data.
+type CallHandler func(call *core.TriggerSmartContract) ([]byte, error)
+
+// Revert error returned by a CallHandler to revert the call
+type Revert struct {
+	Data []byte
+}
+
+// Error implements error
+func (r *Revert) Error() string {
+	return "REVERT opcode executed"
+}
+
+// Node in-memory TRON full node
+type Node struct {
+	api.UnimplementedWalletServer
+
+	mu          sync.Mutex
+	autoProduce bool
+	accounts    map[string]*core.Account
+	resources   map[string]*api.AccountResourceMessage
+	assets      []*core.AssetIssueContract
+	contracts   map[string]*core.SmartContract
+	handlers    map[string]CallHandler
+	blocks      []*core.Block
+	blockIDs    [][]byte
+	pending     []*core.Transaction
+	txs         map[string]*core.Transaction
+	infos       map[string]*core.TransactionInfo
+	lastTx      int64
+	forks       int64
+
+	// EnergyPrices returned by GetEnergyPrices
+	EnergyPrices string
+	// BandwidthPrices returned by GetBandwidthPrices
+	BandwidthPrices string
+	// MemoFee returned by GetMemoFee, burnt by transactions with a memo
+	MemoFee string
+	// EnergyPerCall energy reported for every smart contract call
+	EnergyPerCall int64
+	// ChainParameters returned by GetChainParameters, mainnet values by default
+	ChainParameters map[string]int64
+	// SolidityLag blocks the solidity API trails the head
+	SolidityLag int64
+
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+// WithManualBlocks keep broadcast transactions pending until ProduceBlock
+func WithManualBlocks() func(*Node) {
+	return func(n *Node) {
+		n.autoProduce = false
+	}
+}
+
+// WithGenesisTime set the genesis block timestamp, now by default
+func WithGenesisTime(t time.Time) func(*Node) {
+	return func(n *Node) {
+		n.blocks[0].BlockHeader.RawData.Timestamp = t.UnixMilli()
+		n.blockIDs[0] = blockID(n.blocks[0])
+	}
+}
+
+// NewNode create and serve a node holding only the genesis block
+func NewNode(options ...func(*Node)) *Node {
+	n := &Node{
+		autoProduce:     true,
+		accounts:        make(map[string]*core.Account),
+		resources:       make(map[string]*api.AccountResourceMessage),
+		contracts:       make(map[string]*core.SmartContract),
+		handlers:        make(map[string]CallHandler),
+		txs:             make(map[string]*core.Transaction),
+		infos:           make(map[string]*core.TransactionInfo),
+		EnergyPrices:    "0:420",
+		BandwidthPrices: "0:1000",
+		MemoFee:         "0:1000000",
+		EnergyPerCall:   14650,
+		ChainParameters: mainnetParameters(),
+		listener:        bufconn.Listen(bufSize),
+		server:          grpc.NewServer(),
+	}
+	genesis := &core.Block{
+		BlockHeader: &core.BlockHeader{
+			RawData: &core.BlockHeaderRaw{Timestamp: time.Now().UnixMilli()},
+		},
+	}
+	n.blocks = []*core.Block{genesis}
+	n.blockIDs = [][]byte{blockID(genesis)}
+	for _, option := range options {
+		option(n)
+	}
+
+	api.RegisterWalletServer(n.server, n)
+	api.RegisterWalletSolidityServer(n.server, &solidity{n: n})
+	api.RegisterDatabaseServer(n.server, &database{n: n})
+	go n.server.Serve(n.listener)
+	return n
+}
+
+// Close stop serving
+func (n *Node) Close() {
+	n.server.Stop()
+}
+
+// DialOptions connect a client started on Address to the node
+func (n *Node) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return n.listener.DialContext(ctx)
+		}),
+	}
+}
+
+// Client create a started client connected to the node
+func (n *Node) Client(opts ...grpc.DialOption) (*client.Client, error) {
+	c := client.New(Address)
+	if err := c.Start(append(n.DialOptions(), opts...)...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Fund set the TRX balance in SUN of a base58 address, creating the account
+func (n *Node) Fund(addr string, balance int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.account(mustDecode(addr), true).Balance = balance
+}
+
+// SetResources set the bandwidth and energy reported by GetAccountResource
+// for a base58 address, 600 free bandwidth only by default
+func (n *Node) SetResources(addr string, res *api.AccountResourceMessage) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.resources[string(mustDecode(addr))] = res
+}
+
+// Balance return the TRX balance in SUN of a base58 address
+func (n *Node) Balance(addr string) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if acc := n.account(mustDecode(addr), false); acc != nil {
+		return acc.Balance
+	}
+	return 0
+}
+
+// IssueAsset create a TRC10 token owned by a base58 address holding the
+// whole supply and return its id
+func (n *Node) IssueAsset(owner, name string, supply int64, precision int32) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ownerAddr := mustDecode(owner)
+	id := strconv.Itoa(firstAssetID + len(n.assets))
+	n.assets = append(n.assets, &core.AssetIssueContract{
+		Id:           id,
+		OwnerAddress: ownerAddr,
+		Name:         []byte(name),
+		Abbr:         []byte(name),
+		TotalSupply:  supply,
+		Precision:    precision,
+		TrxNum:       1,
+		Num:          1,
+	})
+	acc := n.account(ownerAddr, true)
+	acc.AssetIssued_ID = []byte(id)
+	acc.AssetIssuedName = []byte(name)
+	acc.AssetV2[id] = supply
+	return id
+}
+
+// AssetBalance return the TRC10 balance of a base58 address
+func (n *Node) AssetBalance(addr, id string) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if acc := n.account(mustDecode(addr), false); acc != nil {
+		return acc.AssetV2[id]
+	}
+	return 0
+}
+
+// SetContract register a smart contract at its base58 address, returned
+// as is by GetContract
+func (n *Node) SetContract(addr string, contract *core.SmartContract) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	contractAddr := mustDecode(addr)
+	contract = proto.Clone(contract).(*core.SmartContract)
+	contract.ContractAddress = contractAddr
+	n.contracts[string(contractAddr)] = contract
+	n.account(contractAddr, true).Type = core.AccountType_Contract
+}
+
+// HandleCall execute calls of method, a signature such as
+// "balanceOf(address)", on the contract at a base58 address with handler.
+// An empty method handles every call without a dedicated handler. Handlers
+// run with the node locked and must not call back into it.
+func (n *Node) HandleCall(contract, method string, handler CallHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	contractAddr := mustDecode(contract)
+	if _, ok := n.contracts[string(contractAddr)]; !ok {
+		n.contracts[string(contractAddr)] = &core.SmartContract{ContractAddress: contractAddr}
+		n.account(contractAddr, true).Type = core.AccountType_Contract
+	}
+	n.handlers[handlerKey(contractAddr, selector(method))] = handler
+}
+
+// ProduceBlock pack pending transactions into a new block
+func (n *Node) ProduceBlock() *core.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.produce()
+}
+
+// Reorg switch to a fork replacing the last depth blocks with depth+1 new
+// ones. Transactions of orphaned blocks are dropped, the account state they
+// changed is kept.
+func (n *Node) Reorg(depth int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if depth > len(n.blocks)-1 {
+		depth = len(n.blocks) - 1
+	}
+	keep := len(n.blocks) - depth
+	for _, b := range n.blocks[keep:] {
+		for _, tx := range b.Transactions {
+			delete(n.txs, string(txID(tx)))
+			delete(n.infos, string(txID(tx)))
+		}
+	}
+	n.blocks, n.blockIDs = n.blocks[:keep], n.blockIDs[:keep]
+	n.forks++
+	for i := 0; i <= depth; i++ {
+		n.produce()
+	}
+}
+
+// Head return the latest block number
+func (n *Node) Head() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.head().BlockHeader.RawData.Number
+}
+
+// Pending return the number of transactions waiting for a block
+func (n *Node) Pending() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.pending)
+}
+
+func (n *Node) head() *core.Block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) account(addr []byte, create bool) *core.Account {
+	acc, ok := n.accounts[string(addr)]
+	if !ok && create {
+		acc = &core.Account{
+			Address:    addr,
+			CreateTime: n.head().BlockHeader.RawData.Timestamp,
+			AssetV2:    make(map[string]int64),
+		}
+		n.accounts[string(addr)] = acc
+	}
+	return acc
+}
+
+func (n *Node) asset(id string) *core.AssetIssueContract {
+	for _, a := range n.assets {
+		if a.Id == id || string(a.Name) == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (n *Node) handler(call *core.TriggerSmartContract) CallHandler {
+	var sel []byte
+	if len(call.Data) >= 4 {
+		sel = call.Data[:4]
+	}
+	if h, ok := n.handlers[handlerKey(call.ContractAddress, sel)]; ok {
+		return h
+	}
+	return n.handlers[handlerKey(call.ContractAddress, nil)]
+}
+
+// produce seal pending transactions, caller holds the lock
+func (n *Node) produce() *core.Block {
+	parent := n.head()
+	raw := &core.BlockHeaderRaw{
+		Number:     parent.BlockHeader.RawData.Number + 1,
+		Timestamp:  parent.BlockHeader.RawData.Timestamp + BlockInterval.Milliseconds(),
+		ParentHash: n.blockIDs[len(n.blockIDs)-1],
+		// forks produce different ids for the same height
+		WitnessId: n.forks,
+		Version:   30,
+	}
+	block := &core.Block{BlockHeader: &core.BlockHeader{RawData: raw}}
+	for _, tx := range n.pending {
+		info := n.execute(tx)
+		info.BlockNumber = raw.Number
+		info.BlockTimeStamp = raw.Timestamp
+		id := string(info.Id)
+		n.txs[id] = tx
+		n.infos[id] = info
+		block.Transactions = append(block.Transactions, tx)
+	}
+	n.pending = nil
+	n.blocks = append(n.blocks, block)
+	n.blockIDs = append(n.blockIDs, blockID(block))
+	return block
+}
+
+// mainnetParameters chain parameters of mainnet
+func mainnetParameters() map[string]int64 {
+	return map[string]int64{
+		"getMaintenanceTimeInterval":             21600000,
+		"getCreateAccountFee":                    100000,
+		"getTransactionFee":                      1000,
+		"getAssetIssueFee":                       1024000000,
+		"getCreateNewAccountFeeInSystemContract": 1000000,
+		"getCreateNewAccountBandwidthRate":       1,
+		"getEnergyFee":                           420,
+		"getTotalEnergyLimit":                    90000000000,
+		"getTotalEnergyCurrentLimit":             90000000000,
+		"getFreeNetLimit":                        600,
+		"getTotalNetLimit":                       43200000000,
+		"getUpdateAccountPermissionFee":          100000000,
+		"getMultiSignFee":                        1000000,
+		"getMemoFee":                             1000000,
+		"getMaxFeeLimit":                         15000000000,
+		"getUnfreezeDelayDays":                   14,
+		"getAllowMultiSign":                      1,
+		"getAllowSameTokenName":                  1,
+		"getAllowDelegateResource":               1,
+		"getAllowTvmTransferTrc10":               1,
+		"getAllowNewResourceModel":               1,
+		"getAllowTvmCompatibleEvm":               1,
+		"getAllowDynamicEnergy":                  1,
+	}
+}
+
+// blockID block number followed by the tail of the header hash
+func blockID(b *core.Block) []byte {
+	raw, _ := proto.Marshal(b.BlockHeader.RawData)
+	hash := sha256.Sum256(raw)
+	binary.BigEndian.PutUint64(hash[:8], uint64(b.BlockHeader.RawData.Number))
+	return hash[:]
+}
+
+// txID hash of the transaction raw data
+func txID(tx *core.Transaction) []byte {
+	raw, _ := proto.Marshal(tx.GetRawData())
+	hash := sha256.Sum256(raw)
+	return hash[:]
+}
+
+func selector(method string) []byte {
+	if len(method) == 0 {
+		return nil
+	}
+	return common.Keccak256([]byte(method))[:4]
+}
+
+func handlerKey(contract, sel []byte) string {
+	return string(contract) + string(sel)
+}
+
+func mustDecode(addr string) []byte {
+	b, err := common.DecodeCheck(addr)
+	if err != nil {
+		panic(fmt.Sprintf("clienttest: invalid address %q: %v", addr, err))
+	}
+	return b
+}
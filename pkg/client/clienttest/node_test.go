@@ -0,0 +1,201 @@
+package clienttest_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	recipient = "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"
+	usdt      = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+)
+
+func newSigner(t *testing.T) (*keystore.KeyStore, *keystore.Account) {
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	return ks, &acct
+}
+
+func confirm(c *transaction.Controller) {
+	c.Behavior.ConfirmationWaitTime = 1
+}
+
+func TestTransferEndToEnd(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks, acct := newSigner(t)
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+
+	tx, err := c.Transfer(context.Background(), owner, recipient, 1_500_000)
+	require.Nil(t, err)
+	ctrlr := transaction.NewController(c, ks, acct, tx.Transaction, confirm)
+	require.Nil(t, ctrlr.ExecuteTransaction(context.Background()))
+	require.Nil(t, ctrlr.GetResultError())
+	require.Equal(t, api.Return_SUCCESS, ctrlr.Result.Code)
+	require.Equal(t, int64(1), ctrlr.Receipt.BlockNumber)
+
+	require.Equal(t, int64(8_500_000), node.Balance(owner))
+	acc, err := c.GetAccountDetailed(context.Background(), recipient)
+	require.Nil(t, err)
+	require.Equal(t, int64(1_500_000), acc.Balance)
+
+	block, err := c.GetBlockByNum(context.Background(), 1)
+	require.Nil(t, err)
+	require.Len(t, block.Transactions, 1)
+	require.Equal(t, tx.Txid, block.Transactions[0].Txid)
+
+	_, err = c.Transfer(context.Background(), owner, recipient, 100_000_000)
+	require.EqualError(t, err, "balance is not sufficient")
+}
+
+func TestTransferAssetEndToEnd(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks, acct := newSigner(t)
+	owner := acct.Address.String()
+	id := node.IssueAsset(owner, "TEST", 1_000, 0)
+
+	tx, err := c.TransferAsset(context.Background(), owner, recipient, id, 400)
+	require.Nil(t, err)
+	ctrlr := transaction.NewController(c, ks, acct, tx.Transaction, confirm)
+	require.Nil(t, ctrlr.ExecuteTransaction(context.Background()))
+
+	require.Equal(t, int64(600), node.AssetBalance(owner, id))
+	require.Equal(t, int64(400), node.AssetBalance(recipient, id))
+	asset, err := c.GetAssetIssueByID(context.Background(), id)
+	require.Nil(t, err)
+	require.Equal(t, "TEST", string(asset.Name))
+}
+
+func TestTRC20EndToEnd(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks, acct := newSigner(t)
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+
+	balances := map[string]*big.Int{owner: big.NewInt(1_000_000)}
+	holder := func(word []byte) string {
+		return address.Address(append([]byte{address.TronBytePrefix}, word[12:]...)).String()
+	}
+	node.HandleCall(usdt, "decimals()", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes(big.NewInt(6).Bytes(), 32), nil
+	})
+	node.HandleCall(usdt, "balanceOf(address)", func(call *core.TriggerSmartContract) ([]byte, error) {
+		balance, ok := balances[holder(call.Data[4:36])]
+		if !ok {
+			balance = new(big.Int)
+		}
+		return common.LeftPadBytes(balance.Bytes(), 32), nil
+	})
+	node.HandleCall(usdt, "transfer(address,uint256)", func(call *core.TriggerSmartContract) ([]byte, error) {
+		from := address.Address(call.OwnerAddress).String()
+		amount := new(big.Int).SetBytes(call.Data[36:68])
+		if balances[from] == nil || balances[from].Cmp(amount) < 0 {
+			return nil, &clienttest.Revert{}
+		}
+		to := holder(call.Data[4:36])
+		if balances[to] == nil {
+			balances[to] = new(big.Int)
+		}
+		balances[from].Sub(balances[from], amount)
+		balances[to].Add(balances[to], amount)
+		return common.LeftPadBytes([]byte{1}, 32), nil
+	})
+
+	decimals, err := c.TRC20GetDecimals(context.Background(), usdt)
+	require.Nil(t, err)
+	require.Equal(t, int64(6), decimals.Int64())
+
+	tx, err := c.TRC20Send(context.Background(), owner, recipient, usdt, big.NewInt(250_000), 10_000_000)
+	require.Nil(t, err)
+	ctrlr := transaction.NewController(c, ks, acct, tx.Transaction, confirm)
+	require.Nil(t, ctrlr.ExecuteTransaction(context.Background()))
+	require.Nil(t, ctrlr.GetResultError())
+
+	balance, err := c.TRC20ContractBalance(context.Background(), recipient, usdt)
+	require.Nil(t, err)
+	require.Equal(t, int64(250_000), balance.Int64())
+
+	tx, err = c.TRC20Send(context.Background(), owner, recipient, usdt, big.NewInt(5_000_000), 10_000_000)
+	require.Nil(t, err)
+	// keystore wipes the key after signing
+	require.Nil(t, ks.Lock(acct.Address))
+	require.Nil(t, ks.Unlock(*acct, "secret"))
+	ctrlr = transaction.NewController(c, ks, acct, tx.Transaction, confirm)
+	require.Nil(t, ctrlr.ExecuteTransaction(context.Background()))
+	require.EqualError(t, ctrlr.GetResultError(), "REVERT opcode executed")
+	require.Equal(t, core.Transaction_Result_REVERT, ctrlr.Receipt.Receipt.Result)
+}
+
+func TestBroadcastValidation(t *testing.T) {
+	node := clienttest.NewNode(clienttest.WithManualBlocks())
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks, acct := newSigner(t)
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+
+	tx, err := c.Transfer(context.Background(), owner, recipient, 1_000_000)
+	require.Nil(t, err)
+
+	result, err := c.Broadcast(context.Background(), tx.Transaction)
+	require.NotNil(t, err)
+	require.Equal(t, api.Return_SIGERROR, result.Code)
+
+	otherKs, other := newSigner(t)
+	forged, err := otherKs.SignTx(*other, proto.Clone(tx.Transaction).(*core.Transaction))
+	require.Nil(t, err)
+	result, err = c.Broadcast(context.Background(), forged)
+	require.NotNil(t, err)
+	require.Equal(t, api.Return_SIGERROR, result.Code)
+
+	_, err = ks.SignTx(*acct, tx.Transaction)
+	require.Nil(t, err)
+
+	result, err = c.Broadcast(context.Background(), tx.Transaction)
+	require.Nil(t, err)
+	require.Equal(t, api.Return_SUCCESS, result.Code)
+	require.Equal(t, 1, node.Pending())
+
+	result, err = c.Broadcast(context.Background(), tx.Transaction)
+	require.NotNil(t, err)
+	require.Equal(t, api.Return_DUP_TRANSACTION_ERROR, result.Code)
+
+	_, err = c.GetTransactionInfoByID(context.Background(), common.BytesToHexString(tx.Txid)[2:])
+	require.NotNil(t, err)
+	node.ProduceBlock()
+	info, err := c.GetTransactionInfoByID(context.Background(), common.BytesToHexString(tx.Txid)[2:])
+	require.Nil(t, err)
+	require.Equal(t, int64(1), info.BlockNumber)
+	require.Equal(t, int64(9_000_000), node.Balance(owner))
+}
@@ -0,0 +1,180 @@
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetPermissions replace the owner and active permissions of the account at
+// a base58 address, as AccountPermissionUpdateContract would. Active
+// permissions without operations allow every contract type.
+func (n *Node) SetPermissions(addr string, owner *core.Permission, actives ...*core.Permission) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	acc := n.account(mustDecode(addr), true)
+	acc.OwnerPermission = proto.Clone(owner).(*core.Permission)
+	acc.OwnerPermission.Type = core.Permission_Owner
+	acc.OwnerPermission.Id = 0
+	acc.ActivePermission = nil
+	for i, active := range actives {
+		active = proto.Clone(active).(*core.Permission)
+		active.Type = core.Permission_Active
+		active.Id = int32(i + 2)
+		if len(active.Operations) == 0 {
+			active.Operations = bytes.Repeat([]byte{0xff}, 32)
+		}
+		acc.ActivePermission = append(acc.ActivePermission, active)
+	}
+}
+
+// permission the contract is signed with, accounts without permissions set
+// have an owner and an active permission holding their own key
+func (n *Node) permission(owner []byte, contract *core.Transaction_Contract) (*core.Permission, error) {
+	acc := n.account(owner, false)
+	id := contract.GetPermissionId()
+	defaultKeys := []*core.Key{{Address: owner, Weight: 1}}
+	var perm *core.Permission
+	switch {
+	case id == 0 && acc.GetOwnerPermission() != nil:
+		perm = acc.GetOwnerPermission()
+	case id == 0:
+		perm = &core.Permission{Type: core.Permission_Owner, PermissionName: "owner", Threshold: 1, Keys: defaultKeys}
+	case id == 1:
+		return nil, fmt.Errorf("witness permission can not sign transactions")
+	case len(acc.GetActivePermission()) == 0 && id == 2:
+		perm = &core.Permission{
+			Type:           core.Permission_Active,
+			Id:             2,
+			PermissionName: "active",
+			Threshold:      1,
+			Operations:     bytes.Repeat([]byte{0xff}, 32),
+			Keys:           defaultKeys,
+		}
+	default:
+		for _, active := range acc.GetActivePermission() {
+			if active.Id == id {
+				perm = active
+			}
+		}
+	}
+	if perm == nil {
+		return nil, fmt.Errorf("permission isn't exit")
+	}
+	kind := int(contract.GetType())
+	if perm.Type == core.Permission_Active && (kind/8 >= len(perm.Operations) || perm.Operations[kind/8]&(1<<(kind%8)) == 0) {
+		return nil, fmt.Errorf("permission denied")
+	}
+	return perm, nil
+}
+
+// signWeight sum the key weights of the signers of tx, failing with the sign
+// weight result code of a signature outside perm
+func signWeight(perm *core.Permission, tx *core.Transaction) (int64, [][]byte, api.TransactionSignWeight_ResultResponseCode, error) {
+	signers, err := approved(tx)
+	if err != nil {
+		return 0, nil, api.TransactionSignWeight_Result_SIGNATURE_FORMAT_ERROR, err
+	}
+	var weight int64
+	for i, signer := range signers {
+		for _, prior := range signers[:i] {
+			if bytes.Equal(prior, signer) {
+				return 0, nil, api.TransactionSignWeight_Result_PERMISSION_ERROR,
+					fmt.Errorf("%s has signed twice!", address.Address(signer))
+			}
+		}
+		var key *core.Key
+		for _, k := range perm.GetKeys() {
+			if bytes.Equal(k.Address, signer) {
+				key = k
+			}
+		}
+		if key == nil {
+			return 0, nil, api.TransactionSignWeight_Result_PERMISSION_ERROR,
+				fmt.Errorf("%s is not contained of permission", address.Address(signer))
+		}
+		weight += key.Weight
+	}
+	return weight, signers, api.TransactionSignWeight_Result_ENOUGH_PERMISSION, nil
+}
+
+// approved recover the signer addresses of tx
+func approved(tx *core.Transaction) ([][]byte, error) {
+	rawBytes, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawBytes)
+	signers := make([][]byte, 0, len(tx.GetSignature()))
+	for _, sig := range tx.GetSignature() {
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			return nil, fmt.Errorf("validate signature error: %v", err)
+		}
+		signers = append(signers, address.PubkeyToAddress(*pub))
+	}
+	return signers, nil
+}
+
+// GetTransactionSignWeight weight of the signatures against the permission
+func (n *Node) GetTransactionSignWeight(_ context.Context, in *core.Transaction) (*api.TransactionSignWeight, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	result := &api.TransactionSignWeight{
+		Result:      &api.TransactionSignWeight_Result{},
+		Transaction: &api.TransactionExtention{Transaction: in, Txid: txID(in)},
+	}
+	fail := func(code api.TransactionSignWeight_ResultResponseCode, err error) (*api.TransactionSignWeight, error) {
+		result.Result.Code, result.Result.Message = code, err.Error()
+		return result, nil
+	}
+	contracts := in.GetRawData().GetContract()
+	if len(contracts) != 1 || contracts[0].Parameter == nil {
+		return fail(api.TransactionSignWeight_Result_OTHER_ERROR, fmt.Errorf("transaction must contain exactly one contract"))
+	}
+	contract, err := contracts[0].Parameter.UnmarshalNew()
+	if err != nil {
+		return fail(api.TransactionSignWeight_Result_OTHER_ERROR, err)
+	}
+	owner, ok := contract.(ownerContract)
+	if !ok {
+		return fail(api.TransactionSignWeight_Result_OTHER_ERROR, fmt.Errorf("contract has no owner"))
+	}
+	perm, err := n.permission(owner.GetOwnerAddress(), contracts[0])
+	if err != nil {
+		return fail(api.TransactionSignWeight_Result_PERMISSION_ERROR, err)
+	}
+	result.Permission = perm
+	weight, signers, code, err := signWeight(perm, in)
+	if err != nil {
+		return fail(code, err)
+	}
+	result.CurrentWeight, result.ApprovedList = weight, signers
+	if weight < perm.Threshold {
+		return fail(api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION,
+			fmt.Errorf("Signature weight %d is less than threshold %d", weight, perm.Threshold))
+	}
+	return result, nil
+}
+
+// GetTransactionApprovedList addresses that signed the transaction
+func (n *Node) GetTransactionApprovedList(_ context.Context, in *core.Transaction) (*api.TransactionApprovedList, error) {
+	result := &api.TransactionApprovedList{
+		Result:      &api.TransactionApprovedList_Result{},
+		Transaction: &api.TransactionExtention{Transaction: in, Txid: txID(in)},
+	}
+	signers, err := approved(in)
+	if err != nil {
+		result.Result.Code, result.Result.Message = api.TransactionApprovedList_Result_SIGNATURE_FORMAT_ERROR, err.Error()
+		return result, nil
+	}
+	result.ApprovedList = signers
+	return result, nil
+}
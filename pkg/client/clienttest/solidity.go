@@ -0,0 +1,41 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// solidity serves the solidity API of a node, SolidityLag blocks behind
+type solidity struct {
+	api.UnimplementedWalletSolidityServer
+	n *Node
+}
+
+// solidified return the latest irreversible block number, caller holds the lock
+func (n *Node) solidified() int64 {
+	num := n.head().BlockHeader.RawData.Number - n.SolidityLag
+	if num < 0 {
+		return 0
+	}
+	return num
+}
+
+// GetNowBlock2 implements api.WalletSolidityServer
+func (s *solidity) GetNowBlock2(_ context.Context, _ *api.EmptyMessage) (*api.BlockExtention, error) {
+	s.n.mu.Lock()
+	defer s.n.mu.Unlock()
+	num := s.n.solidified()
+	return proto.Clone(s.n.blockExtention(s.n.blocks[num], s.n.blockIDs[num])).(*api.BlockExtention), nil
+}
+
+// GetBlockByNum2 implements api.WalletSolidityServer
+func (s *solidity) GetBlockByNum2(_ context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	s.n.mu.Lock()
+	defer s.n.mu.Unlock()
+	if in.Num < 0 || in.Num > s.n.solidified() {
+		return &api.BlockExtention{}, nil
+	}
+	return proto.Clone(s.n.blockExtention(s.n.blocks[in.Num], s.n.blockIDs[in.Num])).(*api.BlockExtention), nil
+}
@@ -0,0 +1,598 @@
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type ownerContract interface {
+	proto.Message
+	GetOwnerAddress() []byte
+}
+
+type validateError struct {
+	code api.ReturnResponseCode
+	msg  string
+}
+
+func (e *validateError) Error() string {
+	return e.msg
+}
+
+func validationErrorf(format string, args ...interface{}) error {
+	return &validateError{code: api.Return_CONTRACT_VALIDATE_ERROR, msg: fmt.Sprintf(format, args...)}
+}
+
+func failure(err error) *api.Return {
+	code := api.Return_OTHER_ERROR
+	var ve *validateError
+	if errors.As(err, &ve) {
+		code = ve.code
+	}
+	return &api.Return{Code: code, Message: []byte(err.Error())}
+}
+
+// build validate contract and wrap it into an unsigned transaction
+func (n *Node) build(kind core.Transaction_Contract_ContractType, contract ownerContract, feeLimit int64) *api.TransactionExtention {
+	if err := n.validate(kind, contract); err != nil {
+		return &api.TransactionExtention{Result: failure(err)}
+	}
+	return n.newTransaction(kind, contract, feeLimit)
+}
+
+// newTransaction wrap contract into an unsigned transaction referencing the
+// head block
+func (n *Node) newTransaction(kind core.Transaction_Contract_ContractType, contract ownerContract, feeLimit int64) *api.TransactionExtention {
+	param, err := anypb.New(contract)
+	if err != nil {
+		return &api.TransactionExtention{Result: failure(err)}
+	}
+
+	// timestamps stay unique so identical transfers get distinct ids
+	now := time.Now().UnixMilli()
+	if now <= n.lastTx {
+		now = n.lastTx + 1
+	}
+	n.lastTx = now
+
+	head := n.head().BlockHeader.RawData
+	headID := n.blockIDs[len(n.blockIDs)-1]
+	refBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(refBytes, uint64(head.Number))
+	tx := &core.Transaction{
+		RawData: &core.TransactionRaw{
+			RefBlockBytes: refBytes[6:8],
+			RefBlockHash:  headID[8:16],
+			Expiration:    head.Timestamp + Expiration.Milliseconds(),
+			Timestamp:     now,
+			FeeLimit:      feeLimit,
+			Contract: []*core.Transaction_Contract{{
+				Type:      kind,
+				Parameter: param,
+			}},
+		},
+	}
+	return &api.TransactionExtention{
+		Transaction: tx,
+		Txid:        txID(tx),
+		Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+	}
+}
+
+// validate check contract against the current state, caller holds the lock
+func (n *Node) validate(kind core.Transaction_Contract_ContractType, contract ownerContract) error {
+	owner := n.account(contract.GetOwnerAddress(), false)
+	if owner == nil {
+		return validationErrorf("account[%s] not exists", address.Address(contract.GetOwnerAddress()))
+	}
+	switch c := contract.(type) {
+	case *core.TransferContract:
+		switch {
+		case c.Amount <= 0:
+			return validationErrorf("amount must be greater than 0")
+		case bytes.Equal(c.OwnerAddress, c.ToAddress):
+			return validationErrorf("cannot transfer TRX to yourself")
+		case owner.Balance < c.Amount:
+			return validationErrorf("balance is not sufficient")
+		}
+	case *core.TransferAssetContract:
+		asset := n.asset(string(c.AssetName))
+		switch {
+		case asset == nil:
+			return validationErrorf("no asset %s", c.AssetName)
+		case c.Amount <= 0:
+			return validationErrorf("amount must be greater than 0")
+		case bytes.Equal(c.OwnerAddress, c.ToAddress):
+			return validationErrorf("cannot transfer asset to yourself")
+		case owner.AssetV2[asset.Id] < c.Amount:
+			return validationErrorf("assetBalance is not sufficient")
+		}
+	case *core.TriggerSmartContract:
+		if _, ok := n.contracts[string(c.ContractAddress)]; !ok {
+			return validationErrorf("no contract or not a smart contract")
+		}
+		if owner.Balance < c.CallValue {
+			return validationErrorf("balance is not sufficient")
+		}
+	default:
+		return validationErrorf("contract type %s is not supported by clienttest", kind)
+	}
+	return nil
+}
+
+// execute apply a sealed transaction to the state, caller holds the lock
+func (n *Node) execute(tx *core.Transaction) *core.TransactionInfo {
+	info := &core.TransactionInfo{
+		Id:      txID(tx),
+		Receipt: &core.ResourceReceipt{NetUsage: int64(proto.Size(tx))},
+	}
+	ret := &core.Transaction_Result{ContractRet: core.Transaction_Result_SUCCESS}
+	tx.Ret = []*core.Transaction_Result{ret}
+
+	ct := tx.RawData.Contract[0]
+	contract, _ := ct.Parameter.UnmarshalNew()
+	if err := n.validate(ct.Type, contract.(ownerContract)); err != nil {
+		info.Result = core.TransactionInfo_FAILED
+		info.ResMessage = []byte(err.Error())
+		ret.Ret = core.Transaction_Result_FAILED
+		ret.ContractRet = core.Transaction_Result_UNKNOWN
+		return info
+	}
+
+	switch c := contract.(type) {
+	case *core.TransferContract:
+		n.account(c.OwnerAddress, false).Balance -= c.Amount
+		n.account(c.ToAddress, true).Balance += c.Amount
+	case *core.TransferAssetContract:
+		id := n.asset(string(c.AssetName)).Id
+		n.account(c.OwnerAddress, false).AssetV2[id] -= c.Amount
+		n.account(c.ToAddress, true).AssetV2[id] += c.Amount
+	case *core.TriggerSmartContract:
+		info.ContractAddress = c.ContractAddress
+		info.Receipt.EnergyUsageTotal = n.EnergyPerCall
+		out, err := n.call(c)
+		if err != nil {
+			info.Result = core.TransactionInfo_FAILED
+			info.ResMessage = []byte(err.Error())
+			info.Receipt.Result = core.Transaction_Result_REVERT
+			ret.ContractRet = core.Transaction_Result_REVERT
+			var revert *Revert
+			if errors.As(err, &revert) {
+				info.ContractResult = [][]byte{revert.Data}
+			}
+			return info
+		}
+		n.account(c.OwnerAddress, false).Balance -= c.CallValue
+		n.account(c.ContractAddress, true).Balance += c.CallValue
+		info.Receipt.Result = core.Transaction_Result_SUCCESS
+		info.ContractResult = [][]byte{out}
+	}
+	if len(tx.RawData.Data) > 0 {
+		fee, _ := client.CurrentPrice(&api.PricesResponseMessage{Prices: n.MemoFee})
+		n.account(contract.(ownerContract).GetOwnerAddress(), false).Balance -= fee
+		info.Fee += fee
+	}
+	return info
+}
+
+// call run the handler registered for a contract call, no handler succeeds
+// with empty output
+func (n *Node) call(c *core.TriggerSmartContract) ([]byte, error) {
+	h := n.handler(c)
+	if h == nil {
+		return nil, nil
+	}
+	return h(c)
+}
+
+// verify check transaction validity before accepting it in the pool,
+// caller holds the lock
+func (n *Node) verify(tx *core.Transaction) error {
+	raw := tx.GetRawData()
+	if raw == nil || len(raw.Contract) != 1 || raw.Contract[0].Parameter == nil {
+		return &validateError{code: api.Return_OTHER_ERROR, msg: "transaction must contain exactly one contract"}
+	}
+	id := txID(tx)
+	if _, ok := n.txs[string(id)]; ok {
+		return &validateError{code: api.Return_DUP_TRANSACTION_ERROR, msg: "dup transaction"}
+	}
+	for _, p := range n.pending {
+		if bytes.Equal(txID(p), id) {
+			return &validateError{code: api.Return_DUP_TRANSACTION_ERROR, msg: "dup transaction"}
+		}
+	}
+
+	head := n.head().BlockHeader.RawData
+	if raw.Expiration <= head.Timestamp {
+		return &validateError{code: api.Return_TRANSACTION_EXPIRATION_ERROR, msg: "transaction expired"}
+	}
+	if !n.tapos(raw) {
+		return &validateError{code: api.Return_TAPOS_ERROR, msg: "tapos check failed"}
+	}
+
+	contract, err := raw.Contract[0].Parameter.UnmarshalNew()
+	if err != nil {
+		return &validateError{code: api.Return_OTHER_ERROR, msg: err.Error()}
+	}
+	owner, ok := contract.(ownerContract)
+	if !ok {
+		return validationErrorf("contract type %s is not supported by clienttest", raw.Contract[0].Type)
+	}
+	if len(tx.Signature) == 0 {
+		return &validateError{code: api.Return_SIGERROR, msg: "miss sig or contract"}
+	}
+	perm, err := n.permission(owner.GetOwnerAddress(), raw.Contract[0])
+	if err != nil {
+		return &validateError{code: api.Return_SIGERROR, msg: fmt.Sprintf("validate signature error: %v", err)}
+	}
+	weight, _, _, err := signWeight(perm, tx)
+	if err != nil {
+		return &validateError{code: api.Return_SIGERROR, msg: fmt.Sprintf("validate signature error: %v", err)}
+	}
+	if weight < perm.Threshold {
+		return &validateError{code: api.Return_SIGERROR, msg: fmt.Sprintf(
+			"validate signature error: signature weight %d is less than threshold %d", weight, perm.Threshold)}
+	}
+	return n.validate(raw.Contract[0].Type, owner)
+}
+
+// tapos check the transaction references a known block
+func (n *Node) tapos(raw *core.TransactionRaw) bool {
+	for i := len(n.blockIDs) - 1; i >= 0; i-- {
+		id := n.blockIDs[i]
+		if bytes.Equal(id[6:8], raw.RefBlockBytes) && bytes.Equal(id[8:16], raw.RefBlockHash) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) blockExtention(b *core.Block, id []byte) *api.BlockExtention {
+	ext := &api.BlockExtention{BlockHeader: b.BlockHeader, Blockid: id}
+	for _, tx := range b.Transactions {
+		ext.Transactions = append(ext.Transactions, &api.TransactionExtention{
+			Transaction: tx,
+			Txid:        txID(tx),
+			Result:      &api.Return{Result: true},
+		})
+	}
+	return ext
+}
+
+// GetAccount implements api.WalletServer, unknown accounts are empty
+func (n *Node) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if acc := n.account(in.Address, false); acc != nil {
+		return proto.Clone(acc).(*core.Account), nil
+	}
+	return &core.Account{}, nil
+}
+
+// GetAccountResource implements api.WalletServer
+func (n *Node) GetAccountResource(_ context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if res, ok := n.resources[string(in.Address)]; ok {
+		return proto.Clone(res).(*api.AccountResourceMessage), nil
+	}
+	return &api.AccountResourceMessage{FreeNetLimit: 600}, nil
+}
+
+// GetAccountNet implements api.WalletServer
+func (n *Node) GetAccountNet(_ context.Context, _ *core.Account) (*api.AccountNetMessage, error) {
+	return &api.AccountNetMessage{FreeNetLimit: 600}, nil
+}
+
+// GetRewardInfo implements api.WalletServer
+func (n *Node) GetRewardInfo(_ context.Context, _ *api.BytesMessage) (*api.NumberMessage, error) {
+	return &api.NumberMessage{}, nil
+}
+
+// GetDelegatedResourceAccountIndex implements api.WalletServer
+func (n *Node) GetDelegatedResourceAccountIndex(_ context.Context, in *api.BytesMessage) (*core.DelegatedResourceAccountIndex, error) {
+	return &core.DelegatedResourceAccountIndex{Account: in.Value}, nil
+}
+
+// GetDelegatedResourceAccountIndexV2 implements api.WalletServer
+func (n *Node) GetDelegatedResourceAccountIndexV2(_ context.Context, in *api.BytesMessage) (*core.DelegatedResourceAccountIndex, error) {
+	return &core.DelegatedResourceAccountIndex{Account: in.Value}, nil
+}
+
+// GetAvailableUnfreezeCount implements api.WalletServer
+func (n *Node) GetAvailableUnfreezeCount(_ context.Context, _ *api.GetAvailableUnfreezeCountRequestMessage) (*api.GetAvailableUnfreezeCountResponseMessage, error) {
+	return &api.GetAvailableUnfreezeCountResponseMessage{Count: 32}, nil
+}
+
+// GetCanWithdrawUnfreezeAmount implements api.WalletServer
+func (n *Node) GetCanWithdrawUnfreezeAmount(_ context.Context, _ *api.CanWithdrawUnfreezeAmountRequestMessage) (*api.CanWithdrawUnfreezeAmountResponseMessage, error) {
+	return &api.CanWithdrawUnfreezeAmountResponseMessage{}, nil
+}
+
+// GetCanDelegatedMaxSize implements api.WalletServer
+func (n *Node) GetCanDelegatedMaxSize(_ context.Context, _ *api.CanDelegatedMaxSizeRequestMessage) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	return &api.CanDelegatedMaxSizeResponseMessage{}, nil
+}
+
+// CreateTransaction2 implements api.WalletServer
+func (n *Node) CreateTransaction2(_ context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.build(core.Transaction_Contract_TransferContract, in, 0), nil
+}
+
+// TransferAsset2 implements api.WalletServer
+func (n *Node) TransferAsset2(_ context.Context, in *core.TransferAssetContract) (*api.TransactionExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.build(core.Transaction_Contract_TransferAssetContract, in, 0), nil
+}
+
+// TriggerContract implements api.WalletServer
+func (n *Node) TriggerContract(_ context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.build(core.Transaction_Contract_TriggerSmartContract, in, 0), nil
+}
+
+// TriggerConstantContract implements api.WalletServer, running the call
+// handler without changing state; the caller account may not exist
+func (n *Node) TriggerConstantContract(_ context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.contracts[string(in.ContractAddress)]; !ok {
+		return &api.TransactionExtention{Result: failure(validationErrorf("no contract or not a smart contract"))}, nil
+	}
+	ext := n.newTransaction(core.Transaction_Contract_TriggerSmartContract, in, 0)
+	if ext.Transaction == nil {
+		return ext, nil
+	}
+	ext.EnergyUsed = n.EnergyPerCall
+	out, err := n.call(in)
+	if err != nil {
+		// like java-tron, a SUCCESS result with the error message and a
+		// FAILED transaction ret
+		ext.Result.Message = []byte(err.Error())
+		var revert *Revert
+		if errors.As(err, &revert) {
+			ext.ConstantResult = [][]byte{revert.Data}
+		}
+		ext.Transaction.Ret = []*core.Transaction_Result{{Ret: core.Transaction_Result_FAILED}}
+		return ext, nil
+	}
+	ext.ConstantResult = [][]byte{out}
+	ext.Transaction.Ret = []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}}
+	return ext, nil
+}
+
+// EstimateEnergy implements api.WalletServer
+func (n *Node) EstimateEnergy(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+	ext, _ := n.TriggerConstantContract(ctx, in)
+	if ext.GetResult().GetCode() != api.Return_SUCCESS {
+		return &api.EstimateEnergyMessage{Result: ext.Result}, nil
+	}
+	if ret := ext.GetTransaction().GetRet(); len(ret) > 0 && ret[0].GetRet() == core.Transaction_Result_FAILED {
+		return &api.EstimateEnergyMessage{Result: &api.Return{
+			Code:    api.Return_CONTRACT_EXE_ERROR,
+			Message: ext.GetResult().GetMessage(),
+		}}, nil
+	}
+	return &api.EstimateEnergyMessage{
+		Result:         &api.Return{Result: true, Code: api.Return_SUCCESS},
+		EnergyRequired: n.EnergyPerCall,
+	}, nil
+}
+
+// GetContract implements api.WalletServer
+func (n *Node) GetContract(_ context.Context, in *api.BytesMessage) (*core.SmartContract, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if c, ok := n.contracts[string(in.Value)]; ok {
+		return proto.Clone(c).(*core.SmartContract), nil
+	}
+	return &core.SmartContract{}, nil
+}
+
+// BroadcastTransaction implements api.WalletServer, verifying signature,
+// reference block, expiration and contract before accepting the transaction
+func (n *Node) BroadcastTransaction(_ context.Context, in *core.Transaction) (*api.Return, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := n.verify(in); err != nil {
+		return failure(err), nil
+	}
+	n.pending = append(n.pending, proto.Clone(in).(*core.Transaction))
+	if n.autoProduce {
+		n.produce()
+	}
+	return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+}
+
+// GetNowBlock implements api.WalletServer
+func (n *Node) GetNowBlock(_ context.Context, _ *api.EmptyMessage) (*core.Block, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return proto.Clone(n.head()).(*core.Block), nil
+}
+
+// GetNowBlock2 implements api.WalletServer
+func (n *Node) GetNowBlock2(_ context.Context, _ *api.EmptyMessage) (*api.BlockExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	last := len(n.blocks) - 1
+	return proto.Clone(n.blockExtention(n.blocks[last], n.blockIDs[last])).(*api.BlockExtention), nil
+}
+
+// GetBlockByNum implements api.WalletServer
+func (n *Node) GetBlockByNum(_ context.Context, in *api.NumberMessage) (*core.Block, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if in.Num < 0 || in.Num >= int64(len(n.blocks)) {
+		return &core.Block{}, nil
+	}
+	return proto.Clone(n.blocks[in.Num]).(*core.Block), nil
+}
+
+// GetBlockByNum2 implements api.WalletServer
+func (n *Node) GetBlockByNum2(_ context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if in.Num < 0 || in.Num >= int64(len(n.blocks)) {
+		return &api.BlockExtention{}, nil
+	}
+	return proto.Clone(n.blockExtention(n.blocks[in.Num], n.blockIDs[in.Num])).(*api.BlockExtention), nil
+}
+
+// GetBlockById implements api.WalletServer
+func (n *Node) GetBlockById(_ context.Context, in *api.BytesMessage) (*core.Block, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, id := range n.blockIDs {
+		if bytes.Equal(id, in.Value) {
+			return proto.Clone(n.blocks[i]).(*core.Block), nil
+		}
+	}
+	return &core.Block{}, nil
+}
+
+// GetBlockByLimitNext2 implements api.WalletServer, blocks in [start, end).
+// Like java-tron, ranges over 100 blocks get an empty list.
+func (n *Node) GetBlockByLimitNext2(_ context.Context, in *api.BlockLimit) (*api.BlockListExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	list := &api.BlockListExtention{}
+	if in.EndNum-in.StartNum > 100 {
+		return list, nil
+	}
+	for num := in.StartNum; num < in.EndNum && num < int64(len(n.blocks)); num++ {
+		if num < 0 {
+			continue
+		}
+		list.Block = append(list.Block, n.blockExtention(n.blocks[num], n.blockIDs[num]))
+	}
+	return proto.Clone(list).(*api.BlockListExtention), nil
+}
+
+// GetBlockByLatestNum2 implements api.WalletServer
+func (n *Node) GetBlockByLatestNum2(_ context.Context, in *api.NumberMessage) (*api.BlockListExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	list := &api.BlockListExtention{}
+	start := int64(len(n.blocks)) - in.Num
+	if start < 0 {
+		start = 0
+	}
+	for num := start; num < int64(len(n.blocks)); num++ {
+		list.Block = append(list.Block, n.blockExtention(n.blocks[num], n.blockIDs[num]))
+	}
+	return proto.Clone(list).(*api.BlockListExtention), nil
+}
+
+// GetTransactionById implements api.WalletServer
+func (n *Node) GetTransactionById(_ context.Context, in *api.BytesMessage) (*core.Transaction, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if tx, ok := n.txs[string(in.Value)]; ok {
+		return proto.Clone(tx).(*core.Transaction), nil
+	}
+	return &core.Transaction{}, nil
+}
+
+// GetTransactionInfoById implements api.WalletServer
+func (n *Node) GetTransactionInfoById(_ context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if info, ok := n.infos[string(in.Value)]; ok {
+		return proto.Clone(info).(*core.TransactionInfo), nil
+	}
+	return &core.TransactionInfo{}, nil
+}
+
+// GetTransactionInfoByBlockNum implements api.WalletServer
+func (n *Node) GetTransactionInfoByBlockNum(_ context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	list := &api.TransactionInfoList{}
+	if in.Num < 0 || in.Num >= int64(len(n.blocks)) {
+		return list, nil
+	}
+	for _, tx := range n.blocks[in.Num].Transactions {
+		list.TransactionInfo = append(list.TransactionInfo, n.infos[string(txID(tx))])
+	}
+	return proto.Clone(list).(*api.TransactionInfoList), nil
+}
+
+// GetAssetIssueById implements api.WalletServer
+func (n *Node) GetAssetIssueById(_ context.Context, in *api.BytesMessage) (*core.AssetIssueContract, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if a := n.asset(string(in.Value)); a != nil {
+		return proto.Clone(a).(*core.AssetIssueContract), nil
+	}
+	return &core.AssetIssueContract{}, nil
+}
+
+// GetAssetIssueList implements api.WalletServer
+func (n *Node) GetAssetIssueList(_ context.Context, _ *api.EmptyMessage) (*api.AssetIssueList, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	list := &api.AssetIssueList{AssetIssue: n.assets}
+	return proto.Clone(list).(*api.AssetIssueList), nil
+}
+
+// GetAssetIssueByAccount implements api.WalletServer
+func (n *Node) GetAssetIssueByAccount(_ context.Context, in *core.Account) (*api.AssetIssueList, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	list := &api.AssetIssueList{}
+	for _, a := range n.assets {
+		if bytes.Equal(a.OwnerAddress, in.Address) {
+			list.AssetIssue = append(list.AssetIssue, a)
+		}
+	}
+	return proto.Clone(list).(*api.AssetIssueList), nil
+}
+
+// GetEnergyPrices implements api.WalletServer
+func (n *Node) GetEnergyPrices(_ context.Context, _ *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+	return &api.PricesResponseMessage{Prices: n.EnergyPrices}, nil
+}
+
+// GetBandwidthPrices implements api.WalletServer
+func (n *Node) GetBandwidthPrices(_ context.Context, _ *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+	return &api.PricesResponseMessage{Prices: n.BandwidthPrices}, nil
+}
+
+// GetMemoFee implements api.WalletServer
+func (n *Node) GetMemoFee(_ context.Context, _ *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+	return &api.PricesResponseMessage{Prices: n.MemoFee}, nil
+}
+
+// GetChainParameters implements api.WalletServer
+func (n *Node) GetChainParameters(_ context.Context, _ *api.EmptyMessage) (*core.ChainParameters, error) {
+	keys := make([]string, 0, len(n.ChainParameters))
+	for key := range n.ChainParameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := &core.ChainParameters{}
+	for _, key := range keys {
+		params.ChainParameter = append(params.ChainParameter, &core.ChainParameters_ChainParameter{
+			Key:   key,
+			Value: n.ChainParameters[key],
+		})
+	}
+	return params, nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/elleqt/gotron-sdk/pkg/abi"
 	"github.com/elleqt/gotron-sdk/pkg/address"
@@ -37,8 +38,8 @@ func (g *Client) UpdateEnergyLimitContract(ctx context.Context, from, contractAd
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 
 	return tx, err
@@ -67,8 +68,8 @@ func (g *Client) UpdateSettingContract(ctx context.Context, from, contractAddres
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 
 	return tx, err
@@ -162,8 +163,8 @@ func (g *Client) triggerContract(ctx context.Context, ct *core.TriggerSmartContr
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	if feeLimit > 0 {
 		tx.Transaction.RawData.FeeLimit = feeLimit
@@ -222,8 +223,8 @@ func (g *Client) estimateEnergy(ctx context.Context, ct *core.TriggerSmartContra
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 
 	return tx, err
@@ -249,6 +250,31 @@ func (g *Client) GetEnergyPrices(ctx context.Context) (*api.PricesResponseMessag
 	return result, nil
 }
 
+// GetMemoFee retrieves memo fee history
+func (g *Client) GetMemoFee(ctx context.Context) (*api.PricesResponseMessage, error) {
+	result, err := g.Client.GetMemoFee(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, fmt.Errorf("get memo fee: %v", err)
+	}
+
+	return result, nil
+}
+
+// CurrentPrice latest price of a "timestamp:price,..." history as returned
+// by GetEnergyPrices, GetBandwidthPrices and GetMemoFee
+func CurrentPrice(prices *api.PricesResponseMessage) (int64, error) {
+	history := strings.Split(prices.GetPrices(), ",")
+	latest := strings.SplitN(history[len(history)-1], ":", 2)
+	if len(latest) != 2 {
+		return 0, fmt.Errorf("invalid prices %q", prices.GetPrices())
+	}
+	price, err := strconv.ParseInt(latest[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid prices %q", prices.GetPrices())
+	}
+	return price, nil
+}
+
 // DeployContract and return tx result
 func (g *Client) DeployContract(ctx context.Context, from, contractName string,
 	abi *core.SmartContract_ABI, codeStr string,
@@ -9,12 +9,13 @@ import (
 	"testing"
 
 	"github.com/elleqt/gotron-sdk/pkg/abi"
-	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/contract"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
 	"github.com/elleqt/gotron-sdk/pkg/proto/api"
 	"github.com/elleqt/gotron-sdk/pkg/proto/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -34,12 +35,26 @@ func TestProtoParse(t *testing.T) {
 }
 
 func TestProtoParseR(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
+	node, conn := newTestNode(t)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
 	require.Nil(t, err)
-	block, err := conn.GetBlockByNum(context.Background(), 48763870)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	node.Fund(acct.Address.String(), 100_000_000)
+	node.HandleCall(usdt, "transfer(address,uint256)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes([]byte{1}, 32), nil
+	})
+	tx, err := conn.TRC20Send(context.Background(), acct.Address.String(), accountAddress, usdt, big.NewInt(1_000), 10_000_000)
+	require.Nil(t, err)
+	_, err = ks.SignTx(acct, tx.Transaction)
+	require.Nil(t, err)
+	_, err = conn.Broadcast(context.Background(), tx.Transaction)
 	require.Nil(t, err)
 
+	block, err := conn.GetBlockByNum(context.Background(), node.Head())
+	require.Nil(t, err)
+
+	triggers := 0
 	for _, tx := range block.Transactions {
 		for _, contract := range tx.GetTransaction().GetRawData().GetContract() {
 			switch contract.Type {
@@ -47,18 +62,20 @@ func TestProtoParseR(t *testing.T) {
 				tsc := core.TriggerSmartContract{}
 				err := contract.Parameter.UnmarshalTo(&tsc)
 				require.Nil(t, err)
-				fmt.Println("Its ok.... test contract data")
+				triggers++
 			default:
 				fmt.Println("handle not SC case")
 			}
 		}
 	}
+	require.Equal(t, 1, triggers)
 }
 
 func TestEstimateEnergy(t *testing.T) {
-	conn := client.New("grpc.nile.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	node, conn := newTestNode(t)
+	node.HandleCall("TVSvjZdyDSNocHm7dP3jvCmMNsCnMTPa5W", "transfer(address,uint256)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes([]byte{1}, 32), nil
+	})
 
 	estimate, err := conn.EstimateEnergy(context.Background(),
 		"TTGhREx2pDSxFX555NWz1YwGpiBVPvQA7e",
@@ -69,13 +86,21 @@ func TestEstimateEnergy(t *testing.T) {
 	)
 	require.Nil(t, err)
 	assert.True(t, estimate.Result.Result)
-	assert.Equal(t, estimate.EnergyRequired, int64(14910))
+	assert.Equal(t, node.EnergyPerCall, estimate.EnergyRequired)
 }
 
 func TestGetAccount(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	node, conn := newTestNode(t)
+	statusOf, err := contract.JSONtoABI(`[{"outputs":[{"name":"frozen","type":"uint256"},{"name":"unfreezeAvailableOn","type":"uint64"},{"name":"frozenDate","type":"uint64"},{"name":"pendingInterest","type":"uint256"},{"name":"realizedInterest","type":"uint256"},{"name":"APR","type":"uint32"},{"name":"unfrozen","type":"uint256"},{"name":"availableOn","type":"uint64"},{"name":"lastClaim","type":"uint64"}],"constant":true,"inputs":[{"name":"account","type":"address"}],"name":"statusOf","stateMutability":"View","type":"Function"}]`)
+	require.Nil(t, err)
+	node.SetContract("TBvmoZWgmx3wqvJoDyejSXqWWogy6kCNGp", &core.SmartContract{Abi: statusOf})
+	node.HandleCall("TBvmoZWgmx3wqvJoDyejSXqWWogy6kCNGp", "statusOf(address)", func(*core.TriggerSmartContract) ([]byte, error) {
+		var status []byte
+		for _, word := range []int64{250_000_000, 1_600_000_000, 1_599_000_000, 1_832_000_000, 1_800, 0, 0, 0, 1_601_000_000} {
+			status = append(status, common.LeftPadBytes(big.NewInt(word).Bytes(), 32)...)
+		}
+		return status, nil
+	})
 
 	tx, err := conn.TriggerConstantContract(context.Background(), "",
 		"TBvmoZWgmx3wqvJoDyejSXqWWogy6kCNGp",
@@ -97,12 +122,12 @@ func TestGetAccount(t *testing.T) {
 	err = arg.UnpackIntoMap(result, tx.ConstantResult[0])
 	fmt.Printf("\nUnpack Result ->>> %+v\n\n", result)
 	require.Nil(t, err)
+	require.Equal(t, big.NewInt(250_000_000), result["frozen"])
 }
 
 func TestGetAccount2(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	node, conn := newTestNode(t)
+	node.Fund("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", 5_000_000)
 
 	tx, err := conn.GetAccountDetailed(context.Background(), "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
 	require.Nil(t, err)
@@ -110,9 +135,13 @@ func TestGetAccount2(t *testing.T) {
 }
 
 func TestGetAccountMigrationContract(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
+	node, conn := newTestNode(t)
+	frozenAmount, err := contract.JSONtoABI(`[{"outputs":[{"name":"amount","type":"uint256"}],"constant":true,"inputs":[{"name":"account","type":"address"}],"name":"frozenAmount","stateMutability":"View","type":"Function"}]`)
 	require.Nil(t, err)
+	node.SetContract("TVoo62PAagTvNvZbB796YfZ7dWtqpPNxnL", &core.SmartContract{Abi: frozenAmount})
+	node.HandleCall("TVoo62PAagTvNvZbB796YfZ7dWtqpPNxnL", "frozenAmount(address)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes(big.NewInt(42_000_000).Bytes(), 32), nil
+	})
 
 	tx, err := conn.TriggerConstantContract(context.Background(), "TX8h6Df74VpJsXF6sTDz1QJsq3Ec8dABc3",
 		"TVoo62PAagTvNvZbB796YfZ7dWtqpPNxnL",
@@ -129,15 +158,13 @@ func TestGetAccountMigrationContract(t *testing.T) {
 
 	result := map[string]interface{}{}
 	err = arg.UnpackIntoMap(result, tx.ConstantResult[0])
-	fmt.Println(result["amount"].(*big.Int).Int64())
 	require.Nil(t, err)
+	require.Equal(t, int64(42_000_000), result["amount"].(*big.Int).Int64())
 }
 
 // TestGetEnergyPrices tests the GetEnergyPrices function
 func TestGetEnergyPrices(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	_, conn := newTestNode(t)
 
 	prices, err := conn.GetEnergyPrices(context.Background())
 	require.Nil(t, err)
@@ -165,9 +192,7 @@ func TestGetEnergyPrices(t *testing.T) {
 
 // TestGetBandwidthPrices tests the GetBandwidthPrices function
 func TestGetBandwidthPrices(t *testing.T) {
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	_, conn := newTestNode(t)
 
 	prices, err := conn.GetBandwidthPrices(context.Background())
 	require.Nil(t, err)
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+
+	"github.com/elleqt/gotron-sdk/pkg/abi"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+)
+
+// Errors matching a *ResultError by code with errors.Is
+var (
+	ErrSignature                    = &ResultError{Code: api.Return_SIGERROR}
+	ErrContractValidate             = &ResultError{Code: api.Return_CONTRACT_VALIDATE_ERROR}
+	ErrContractExecution            = &ResultError{Code: api.Return_CONTRACT_EXE_ERROR}
+	ErrBandwidth                    = &ResultError{Code: api.Return_BANDWITH_ERROR}
+	ErrDupTransaction               = &ResultError{Code: api.Return_DUP_TRANSACTION_ERROR}
+	ErrTapos                        = &ResultError{Code: api.Return_TAPOS_ERROR}
+	ErrTooBigTransaction            = &ResultError{Code: api.Return_TOO_BIG_TRANSACTION_ERROR}
+	ErrTransactionExpired           = &ResultError{Code: api.Return_TRANSACTION_EXPIRATION_ERROR}
+	ErrServerBusy                   = &ResultError{Code: api.Return_SERVER_BUSY}
+	ErrNoConnection                 = &ResultError{Code: api.Return_NO_CONNECTION}
+	ErrNotEnoughEffectiveConnection = &ResultError{Code: api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION}
+	ErrBlockUnsolidified            = &ResultError{Code: api.Return_BLOCK_UNSOLIDIFIED}
+	ErrOther                        = &ResultError{Code: api.Return_OTHER_ERROR}
+)
+
+// Errors matching a *ContractError by contract result with errors.Is
+var (
+	ErrRevert         = &ContractError{Result: core.Transaction_Result_REVERT}
+	ErrOutOfEnergy    = &ContractError{Result: core.Transaction_Result_OUT_OF_ENERGY}
+	ErrOutOfTime      = &ContractError{Result: core.Transaction_Result_OUT_OF_TIME}
+	ErrOutOfMemory    = &ContractError{Result: core.Transaction_Result_OUT_OF_MEMORY}
+	ErrTransferFailed = &ContractError{Result: core.Transaction_Result_TRANSFER_FAILED}
+)
+
+// ResultError node refused to build or broadcast a transaction
+type ResultError struct {
+	Code    api.ReturnResponseCode
+	Message string
+}
+
+// Error implements error
+func (e *ResultError) Error() string {
+	if len(e.Message) == 0 {
+		return e.Code.String()
+	}
+	return e.Message
+}
+
+// Is match a *ResultError with the same code
+func (e *ResultError) Is(target error) bool {
+	t, ok := target.(*ResultError)
+	return ok && t.Code == e.Code
+}
+
+// ContractError transaction included in a block but failed executing
+type ContractError struct {
+	Result  core.Transaction_ResultContractResult
+	Message string
+	// Revert decoded revert data, nil when the call returned none
+	Revert *abi.Revert
+}
+
+// Error implements error
+func (e *ContractError) Error() string {
+	msg := e.Message
+	if len(msg) == 0 {
+		msg = e.Result.String()
+	}
+	if e.Revert != nil {
+		return msg + ": " + e.Revert.String()
+	}
+	return msg
+}
+
+// Is match a *ContractError with the same contract result
+func (e *ContractError) Is(target error) bool {
+	t, ok := target.(*ContractError)
+	return ok && t.Result == e.Result
+}
+
+// resultError *ResultError of a failed result, nil on success
+func resultError(result *api.Return) error {
+	if result.GetCode() == api.Return_SUCCESS {
+		return nil
+	}
+	return &ResultError{Code: result.GetCode(), Message: string(result.GetMessage())}
+}
+
+// ReceiptError *ContractError of a failed transaction, nil when it succeeded
+func (g *Client) ReceiptError(ctx context.Context, info *core.TransactionInfo) error {
+	if info.GetResult() == core.TransactionInfo_SUCESS {
+		return nil
+	}
+	return &ContractError{
+		Result:  info.GetReceipt().GetResult(),
+		Message: string(info.GetResMessage()),
+		Revert:  g.RevertReason(ctx, info),
+	}
+}
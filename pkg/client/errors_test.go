@@ -0,0 +1,79 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedErrors(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+	ctx := context.Background()
+
+	_, err = c.Transfer(ctx, owner, accountAddress, 100_000_000)
+	require.True(t, errors.Is(err, client.ErrContractValidate))
+	require.EqualError(t, err, "balance is not sufficient")
+
+	tx, err := c.Transfer(ctx, owner, accountAddress, 1_000_000)
+	require.Nil(t, err)
+	_, err = c.Broadcast(ctx, tx.Transaction)
+	require.True(t, errors.Is(err, client.ErrSignature))
+	var resultErr *client.ResultError
+	require.True(t, errors.As(err, &resultErr))
+	require.Equal(t, api.Return_SIGERROR, resultErr.Code)
+
+	signed, err := ks.SignTx(acct, tx.Transaction)
+	require.Nil(t, err)
+	_, err = c.Broadcast(ctx, signed)
+	require.Nil(t, err)
+	_, err = c.Broadcast(ctx, signed)
+	require.True(t, errors.Is(err, client.ErrDupTransaction))
+	require.False(t, errors.Is(err, client.ErrTapos))
+
+	const token = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	node.HandleCall(token, "transfer(address,uint256)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return nil, &clienttest.Revert{}
+	})
+	call, err := c.TRC20Send(ctx, owner, accountAddress, token, big.NewInt(1), 10_000_000)
+	require.Nil(t, err)
+	require.Nil(t, ks.Lock(acct.Address))
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	ctrlr := transaction.NewController(c, ks, &acct, call.Transaction, func(c *transaction.Controller) {
+		c.Behavior.ConfirmationWaitTime = 5
+	})
+	require.Nil(t, ctrlr.ExecuteTransaction(ctx))
+	err = ctrlr.GetResultError()
+	require.True(t, errors.Is(err, client.ErrRevert))
+	require.False(t, errors.Is(err, client.ErrOutOfEnergy))
+	var contractErr *client.ContractError
+	require.True(t, errors.As(err, &contractErr))
+	require.Equal(t, core.Transaction_Result_REVERT, contractErr.Result)
+	require.Nil(t, contractErr.Revert)
+
+	// the controller surfaces broadcast failures as typed errors too
+	ctrlr = transaction.NewController(c, nil, nil, signed, func(c *transaction.Controller) {
+		c.Behavior.ConfirmationWaitTime = 5
+	})
+	require.True(t, errors.Is(ctrlr.Broadcast(ctx), client.ErrDupTransaction))
+	require.Equal(t, api.Return_DUP_TRANSACTION_ERROR, ctrlr.Result.GetCode())
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// signatureSize bytes a 65 bytes signature adds to a transaction
+	signatureSize = 67
+	// maxResultSize bytes java-tron reserves for the transaction result
+	maxResultSize = 64
+)
+
+// Estimate expected cost of a transaction, fees in SUN
+type Estimate struct {
+	// Bandwidth bytes the transaction consumes once signed
+	Bandwidth int64 `json:"bandwidth"`
+	// BandwidthCovered whether free or staked bandwidth pays for it
+	BandwidthCovered bool  `json:"bandwidthCovered"`
+	BandwidthFee     int64 `json:"bandwidthFee"`
+	// Energy required by a smart contract call
+	Energy int64 `json:"energy"`
+	// EnergyCovered energy paid by staked energy
+	EnergyCovered int64 `json:"energyCovered"`
+	EnergyFee     int64 `json:"energyFee"`
+	// ActivationFee creating the recipient account when it does not exist
+	ActivationFee int64 `json:"activationFee"`
+	MemoFee       int64 `json:"memoFee"`
+	// MultiSignFee charged to transactions with several signatures
+	MultiSignFee int64 `json:"multiSignFee"`
+	// Total TRX burnt
+	Total int64 `json:"total"`
+}
+
+// Estimator options of EstimateCost
+type Estimator struct {
+	// Signatures the transaction carries once signed, 1 by default
+	Signatures int
+}
+
+// EstimateCost what tx costs sender, the account paying for it, at current
+// prices and resources. Smart contract calls are simulated and assume the
+// caller pays all their energy.
+func (g *Client) EstimateCost(ctx context.Context, tx *core.Transaction, sender string,
+	options ...func(*Estimator)) (*Estimate, error) {
+	e := &Estimator{Signatures: 1}
+	for _, option := range options {
+		option(e)
+	}
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("transaction must contain exactly one contract")
+	}
+	contract, err := contracts[0].GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	params, err := g.ChainParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := g.GetAccountResource(ctx, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{Bandwidth: bandwidth(tx, e.Signatures)}
+	if e.Signatures > 1 {
+		estimate.MultiSignFee = params.MultiSignFee
+	}
+	if estimate.MemoFee, err = g.MemoFee(ctx, string(tx.GetRawData().GetData())); err != nil {
+		return nil, err
+	}
+
+	staked := resource.GetNetLimit() - resource.GetNetUsed()
+	free := resource.GetFreeNetLimit() - resource.GetFreeNetUsed()
+	activates, err := g.activates(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if activates {
+		// free bandwidth does not pay for creating accounts
+		estimate.ActivationFee = params.CreateNewAccountFeeInSystemContract
+		estimate.BandwidthCovered = staked >= estimate.Bandwidth*params.CreateNewAccountBandwidthRate
+		if !estimate.BandwidthCovered {
+			estimate.BandwidthFee = params.CreateAccountFee
+		}
+	} else {
+		estimate.BandwidthCovered = staked >= estimate.Bandwidth || free >= estimate.Bandwidth
+		if !estimate.BandwidthCovered {
+			prices, err := g.GetBandwidthPrices(ctx)
+			if err != nil {
+				return nil, err
+			}
+			price, err := CurrentPrice(prices)
+			if err != nil {
+				return nil, err
+			}
+			estimate.BandwidthFee = estimate.Bandwidth * price
+		}
+	}
+
+	if trigger, ok := contract.(*core.TriggerSmartContract); ok {
+		if estimate.Energy, err = g.callEnergy(ctx, trigger); err != nil {
+			return nil, err
+		}
+		available := resource.GetEnergyLimit() - resource.GetEnergyUsed()
+		if available < 0 {
+			available = 0
+		}
+		estimate.EnergyCovered = estimate.Energy
+		if available < estimate.Energy {
+			estimate.EnergyCovered = available
+			prices, err := g.GetEnergyPrices(ctx)
+			if err != nil {
+				return nil, err
+			}
+			price, err := CurrentPrice(prices)
+			if err != nil {
+				return nil, err
+			}
+			estimate.EnergyFee = (estimate.Energy - available) * price
+		}
+	}
+
+	estimate.Total = estimate.BandwidthFee + estimate.EnergyFee + estimate.ActivationFee +
+		estimate.MemoFee + estimate.MultiSignFee
+	return estimate, nil
+}
+
+// bandwidth bytes of tx once carrying signatures
+func bandwidth(tx *core.Transaction, signatures int) int64 {
+	unsigned := &core.Transaction{RawData: tx.GetRawData()}
+	return int64(proto.Size(unsigned)) + int64(signatures*signatureSize) + maxResultSize
+}
+
+// activates whether contract creates its recipient account
+func (g *Client) activates(ctx context.Context, contract proto.Message) (bool, error) {
+	var to []byte
+	switch c := contract.(type) {
+	case *core.TransferContract:
+		to = c.ToAddress
+	case *core.TransferAssetContract:
+		to = c.ToAddress
+	case *core.AccountCreateContract:
+		return true, nil
+	default:
+		return false, nil
+	}
+	acc, err := g.Client.GetAccount(ctx, &core.Account{Address: to})
+	if err != nil {
+		return false, err
+	}
+	return len(acc.GetAddress()) == 0, nil
+}
+
+// callEnergy energy a call requires, simulated when the node does not
+// estimate energy
+func (g *Client) callEnergy(ctx context.Context, trigger *core.TriggerSmartContract) (int64, error) {
+	estimated, err := g.Client.EstimateEnergy(ctx, trigger)
+	if err == nil && estimated.GetResult().GetResult() && len(estimated.GetResult().GetMessage()) == 0 {
+		return estimated.GetEnergyRequired(), nil
+	}
+	simulated, err := g.SimulateCall(ctx, trigger)
+	if err != nil {
+		return 0, err
+	}
+	if !simulated.Success {
+		reason := simulated.Message
+		if simulated.Revert != nil {
+			reason += ": " + simulated.Revert.Reason
+		}
+		return 0, fmt.Errorf("simulating call to %s: %s", address.Address(trigger.GetContractAddress()), reason)
+	}
+	return simulated.EnergyUsed, nil
+}
@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	const (
+		owner = "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"
+		usdt  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	)
+	node.Fund(owner, 100_000_000)
+	ctx := context.Background()
+
+	// activating the recipient burns the creation fees, free bandwidth does not apply
+	tx, err := c.Transfer(ctx, owner, accountAddress, 1_000_000)
+	require.Nil(t, err)
+	estimate, err := c.EstimateCost(ctx, tx.Transaction, owner)
+	require.Nil(t, err)
+	require.False(t, estimate.BandwidthCovered)
+	require.Equal(t, int64(1_000_000), estimate.ActivationFee)
+	require.Equal(t, int64(100_000), estimate.BandwidthFee)
+	require.Equal(t, int64(1_100_000), estimate.Total)
+
+	// free bandwidth covers a transfer to an existing account
+	node.Fund(accountAddress, 1)
+	estimate, err = c.EstimateCost(ctx, tx.Transaction, owner)
+	require.Nil(t, err)
+	require.True(t, estimate.BandwidthCovered)
+	require.Zero(t, estimate.ActivationFee)
+	require.Zero(t, estimate.Total)
+
+	require.Nil(t, c.SetMemo(tx, "deposit 42"))
+	estimate, err = c.EstimateCost(ctx, tx.Transaction, owner, func(e *client.Estimator) {
+		e.Signatures = 2
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(1_000_000), estimate.MemoFee)
+	require.Equal(t, int64(1_000_000), estimate.MultiSignFee)
+	require.Equal(t, int64(2_000_000), estimate.Total)
+
+	// bytes are burnt once free bandwidth is used up
+	node.SetResources(owner, &api.AccountResourceMessage{FreeNetLimit: 600, FreeNetUsed: 600})
+	estimate, err = c.EstimateCost(ctx, tx.Transaction, owner)
+	require.Nil(t, err)
+	require.False(t, estimate.BandwidthCovered)
+	require.Equal(t, estimate.Bandwidth*1000, estimate.BandwidthFee)
+
+	node.HandleCall(usdt, "transfer(address,uint256)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes([]byte{1}, 32), nil
+	})
+	call, err := c.TRC20Send(ctx, owner, accountAddress, usdt, big.NewInt(250_000), 10_000_000)
+	require.Nil(t, err)
+	node.SetResources(owner, &api.AccountResourceMessage{FreeNetLimit: 600, EnergyLimit: 10_000, EnergyUsed: 1_000})
+	estimate, err = c.EstimateCost(ctx, call.Transaction, owner)
+	require.Nil(t, err)
+	require.True(t, estimate.BandwidthCovered)
+	require.Equal(t, int64(14650), estimate.Energy)
+	require.Equal(t, int64(9_000), estimate.EnergyCovered)
+	require.Equal(t, int64(5_650*420), estimate.EnergyFee)
+	require.Equal(t, estimate.EnergyFee, estimate.Total)
+}
@@ -66,8 +66,8 @@ func (g *Client) ExchangeCreate(ctx context.Context,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -97,8 +97,8 @@ func (g *Client) ExchangeInject(ctx context.Context,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -128,8 +128,8 @@ func (g *Client) ExchangeWithdraw(ctx context.Context,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -161,8 +161,8 @@ func (g *Client) ExchangeTrade(ctx context.Context,
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
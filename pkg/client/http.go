@@ -0,0 +1,267 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// httpServicePaths maps gRPC services to their HTTP API prefix
+var httpServicePaths = map[string]string{
+	"protocol.Wallet":         "/wallet/",
+	"protocol.WalletSolidity": "/walletsolidity/",
+}
+
+// httpMethodNames HTTP endpoints not named after the lowercase RPC name
+var httpMethodNames = map[string]string{
+	"BroadcastTransaction":       "broadcasthex",
+	"TriggerContract":            "triggersmartcontract",
+	"GetRewardInfo":              "getReward",
+	"GetBrokerageInfo":           "getBrokerage",
+	"UpdateBrokerage":            "updateBrokerage",
+	"GetTransactionSignWeight":   "getsignweight",
+	"GetTransactionApprovedList": "getapprovedlist",
+	"ClearContractABI":           "clearabi",
+}
+
+// IsHTTPAddress tells if address selects the HTTP API transport
+func IsHTTPAddress(address string) bool {
+	return strings.HasPrefix(address, "http://") || strings.HasPrefix(address, "https://")
+}
+
+// httpConn serve gRPC wallet calls through the java-tron HTTP API
+type httpConn struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newHTTPConn(baseURL string) *httpConn {
+	return &httpConn{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// httpPath return HTTP endpoint for a full gRPC method name
+func httpPath(method string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(method, "/"), "/")
+	if len(parts) != 2 {
+		return "", status.Errorf(codes.Unimplemented, "http: invalid method %s", method)
+	}
+	prefix, ok := httpServicePaths[parts[0]]
+	if !ok {
+		return "", status.Errorf(codes.Unimplemented, "http: service %s not supported", parts[0])
+	}
+	name, ok := httpMethodNames[parts[1]]
+	if !ok {
+		name = parts[1]
+		// GetNowBlock2 -> getnowblock, but FreezeBalanceV2 keeps its suffix
+		if strings.HasSuffix(name, "2") && !strings.HasSuffix(name, "V2") {
+			name = strings.TrimSuffix(name, "2")
+		}
+		name = strings.ToLower(name)
+	}
+	return prefix + name, nil
+}
+
+// httpStatusCode map HTTP failures to gRPC codes so retries behave the same
+func httpStatusCode(code int) codes.Code {
+	switch code {
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	}
+	return codes.Unknown
+}
+
+// requestBody encode call arguments for the endpoint
+func (c *httpConn) requestBody(method string, args interface{}) ([]byte, error) {
+	msg, ok := args.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "http: %T is not a proto message", args)
+	}
+	if tx, ok := msg.(*core.Transaction); ok && isBroadcast(method) {
+		raw, err := proto.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]string{"transaction": hex.EncodeToString(raw)})
+	}
+	return marshalTronJSON(msg)
+}
+
+// Invoke POST the call to the HTTP API and decode the reply
+func (c *httpConn) Invoke(ctx context.Context, method string, args, reply interface{}, _ ...grpc.CallOption) error {
+	path, err := httpPath(method)
+	if err != nil {
+		return err
+	}
+	body, err := c.requestBody(method, args)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		for k, values := range md {
+			for _, v := range values {
+				req.Header.Add(k, v)
+			}
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return status.Errorf(httpStatusCode(resp.StatusCode), "http %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "http: %T is not a proto message", reply)
+	}
+	obj, err := httpResponseObject(data, msg)
+	if err != nil {
+		return err
+	}
+	proto.Reset(msg)
+	if err := jsonToMessage(obj, msg.ProtoReflect()); err != nil {
+		return status.Errorf(codes.Internal, "http: decoding %s: %v", path, err)
+	}
+	return nil
+}
+
+// NewStream streaming calls are not part of the HTTP API
+func (c *httpConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "http: streams not supported")
+}
+
+// httpResponseObject decode the HTTP reply and reshape it into the layout of
+// the gRPC reply message
+func httpResponseObject(data []byte, reply proto.Message) (map[string]interface{}, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	// gettransactioninfobyblocknum replies with a bare list
+	if trimmed[0] == '[' {
+		var list []interface{}
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&list); err != nil {
+			return nil, status.Errorf(codes.Internal, "http: %v", err)
+		}
+		if _, ok := reply.(*api.TransactionInfoList); ok {
+			return map[string]interface{}{"transactionInfo": list}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "http: unexpected list reply for %T", reply)
+	}
+
+	obj, err := decodeJSONObject(trimmed)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "http: %v", err)
+	}
+
+	if msg, ok := obj["Error"]; ok {
+		if _, ok := reply.(*api.TransactionExtention); ok {
+			return map[string]interface{}{
+				"result": map[string]interface{}{
+					"code":    api.Return_OTHER_ERROR.String(),
+					"message": hex.EncodeToString([]byte(fmt.Sprint(msg))),
+				},
+			}, nil
+		}
+		return nil, status.Errorf(codes.Unknown, "%v", msg)
+	}
+
+	switch reply.(type) {
+	case *api.TransactionExtention:
+		return reshapeTransactionExtention(obj), nil
+	case *api.BlockExtention:
+		return reshapeBlockExtention(obj), nil
+	case *api.BlockListExtention:
+		if blocks, ok := obj["block"].([]interface{}); ok {
+			for i, b := range blocks {
+				if block, ok := b.(map[string]interface{}); ok {
+					blocks[i] = reshapeBlockExtention(block)
+				}
+			}
+		}
+	case *api.NumberMessage:
+		for _, key := range []string{"reward", "brokerage"} {
+			if v, ok := obj[key]; ok {
+				obj["num"] = v
+			}
+		}
+	}
+	return obj, nil
+}
+
+// reshapeTransactionExtention wrap plain transactions returned by builders
+func reshapeTransactionExtention(obj map[string]interface{}) map[string]interface{} {
+	if _, ok := obj["raw_data"]; ok {
+		return map[string]interface{}{
+			"transaction": obj,
+			"txid":        obj["txID"],
+			"result":      map[string]interface{}{"result": true},
+		}
+	}
+	if tx, ok := obj["transaction"].(map[string]interface{}); ok {
+		if _, ok := obj["txid"]; !ok {
+			obj["txid"] = tx["txID"]
+		}
+	}
+	return obj
+}
+
+// reshapeBlockExtention map blockID and wrap every transaction
+func reshapeBlockExtention(obj map[string]interface{}) map[string]interface{} {
+	if id, ok := obj["blockID"]; ok {
+		obj["blockid"] = id
+	}
+	if txs, ok := obj["transactions"].([]interface{}); ok {
+		for i, t := range txs {
+			if tx, ok := t.(map[string]interface{}); ok {
+				txs[i] = map[string]interface{}{"transaction": tx, "txid": tx["txID"]}
+			}
+		}
+	}
+	return obj
+}
@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func startHTTPNode(t *testing.T) (*httptest.Server, *core.Transaction) {
+	from, _ := address.Base58ToAddress(accountAddress)
+	to, _ := address.Base58ToAddress(testnetNileAddressExample)
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: from, ToAddress: to, Amount: 1000})
+	require.Nil(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		RefBlockBytes: []byte{0x01, 0x02},
+		RefBlockHash:  []byte{0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+		Expiration:    1700000060000,
+		Timestamp:     1700000000000,
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TransferContract,
+			Parameter: param,
+		}},
+	}}
+	rawData, err := proto.Marshal(tx.RawData)
+	require.Nil(t, err)
+	txID := sha256.Sum256(rawData)
+
+	mux := http.NewServeMux()
+	reply := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/wallet/getaccount", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["address"] == hex.EncodeToString(to) {
+			// base58 instead of hex, as with visible=true
+			reply(w, map[string]interface{}{"address": testnetNileAddressExample})
+			return
+		}
+		reply(w, map[string]interface{}{
+			"address": req["address"],
+			"balance": 2500000,
+			"assetV2": []map[string]interface{}{{"key": "1002000", "value": 7}},
+		})
+	})
+	mux.HandleFunc("/wallet/createtransaction", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["to_address"] != hex.EncodeToString(to) {
+			reply(w, map[string]string{"Error": "invalid to_address"})
+			return
+		}
+		reply(w, map[string]interface{}{
+			"txID": hex.EncodeToString(txID[:]),
+			"raw_data": map[string]interface{}{
+				"contract": []interface{}{map[string]interface{}{
+					"type": "TransferContract",
+					"parameter": map[string]interface{}{
+						"type_url": "type.googleapis.com/protocol.TransferContract",
+						"value": map[string]interface{}{
+							"owner_address": hex.EncodeToString(from),
+							"to_address":    req["to_address"],
+							"amount":        req["amount"],
+						},
+					},
+				}},
+				"ref_block_bytes": "0102",
+				"ref_block_hash":  "030405060708090a",
+				"expiration":      1700000060000,
+				"timestamp":       1700000000000,
+			},
+			"raw_data_hex": hex.EncodeToString(rawData),
+		})
+	})
+	mux.HandleFunc("/wallet/broadcasthex", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		raw, _ := hex.DecodeString(req["transaction"])
+		sent := new(core.Transaction)
+		if err := proto.Unmarshal(raw, sent); err != nil || len(sent.Signature) == 0 {
+			reply(w, map[string]interface{}{"result": false, "code": "SIGERROR", "message": hex.EncodeToString([]byte("no signature"))})
+			return
+		}
+		reply(w, map[string]interface{}{"result": true, "txid": hex.EncodeToString(txID[:])})
+	})
+	mux.HandleFunc("/wallet/getnowblock", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(client.APIKeyHeader) != "http-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reply(w, map[string]interface{}{
+			"blockID":      "00000000000000640000000000000000000000000000000000000000000000aa",
+			"block_header": map[string]interface{}{"raw_data": map[string]interface{}{"number": 100}},
+			"transactions": []interface{}{map[string]interface{}{
+				"txID":         hex.EncodeToString(txID[:]),
+				"raw_data_hex": hex.EncodeToString(rawData),
+			}},
+		})
+	})
+	mux.HandleFunc("/wallet/gettransactioninfobyblocknum", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, fmt.Sprintf(`[{"id":"%s","blockNumber":100,"fee":1100000}]`, hex.EncodeToString(txID[:])))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, tx
+}
+
+func TestHTTPTransport(t *testing.T) {
+	srv, expected := startHTTPNode(t)
+
+	c := client.New(srv.URL)
+	require.Nil(t, c.SetAPIKey("http-key"))
+	require.Nil(t, c.Start())
+	defer c.Stop()
+
+	acc, err := c.GetAccount(context.Background(), accountAddress)
+	require.Nil(t, err)
+	require.Equal(t, int64(2500000), acc.Balance)
+	require.Equal(t, int64(7), acc.AssetV2["1002000"])
+
+	_, err = c.GetAccount(context.Background(), testnetNileAddressExample)
+	require.ErrorContains(t, err, "field address: expected hex bytes")
+
+	tx, err := c.Transfer(context.Background(), accountAddress, testnetNileAddressExample, 1000)
+	require.Nil(t, err)
+	require.True(t, proto.Equal(expected.RawData, tx.Transaction.RawData))
+	rawData, _ := proto.Marshal(tx.Transaction.RawData)
+	txID := sha256.Sum256(rawData)
+	require.Equal(t, txID[:], tx.Txid)
+
+	_, err = c.Transfer(context.Background(), accountAddress, accountAddress, 1000)
+	require.EqualError(t, err, "invalid to_address")
+
+	_, err = c.Broadcast(context.Background(), tx.Transaction)
+	require.EqualError(t, err, "result error: no signature")
+	tx.Transaction.Signature = [][]byte{make([]byte, 65)}
+	result, err := c.Broadcast(context.Background(), tx.Transaction)
+	require.Nil(t, err)
+	require.True(t, result.Result)
+
+	block, err := c.GetNowBlock(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int64(100), block.BlockHeader.RawData.Number)
+	require.Len(t, block.Transactions, 1)
+	require.Equal(t, txID[:], block.Transactions[0].Txid)
+	require.True(t, proto.Equal(expected.RawData, block.Transactions[0].Transaction.RawData))
+
+	info, err := c.GetBlockInfoByNum(context.Background(), 100)
+	require.Nil(t, err)
+	require.Len(t, info.TransactionInfo, 1)
+	require.Equal(t, int64(1100000), info.TransactionInfo[0].Fee)
+}
@@ -0,0 +1,299 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The java-tron HTTP API speaks its own JSON dialect of the protobuf
+// messages: proto field names, bytes as hex, enums by name, maps as lists
+// of key/value objects and Any as {"type_url", "value"} with a decoded value.
+
+// marshalTronJSON encode message in java-tron JSON
+func marshalTronJSON(m proto.Message) ([]byte, error) {
+	obj, err := messageToJSON(m.ProtoReflect())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+func decodeJSONObject(data []byte) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func messageToJSON(m protoreflect.Message) (map[string]interface{}, error) {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		inner, err := a.UnmarshalNew()
+		if err != nil {
+			return nil, err
+		}
+		value, err := messageToJSON(inner.ProtoReflect())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type_url": a.GetTypeUrl(), "value": value}, nil
+	}
+
+	obj := make(map[string]interface{})
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		var value interface{}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			values := make([]interface{}, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				if value, err = singularToJSON(fd, list.Get(i)); err != nil {
+					return false
+				}
+				values = append(values, value)
+			}
+			value = values
+		case fd.IsMap():
+			entries := make([]interface{}, 0, v.Map().Len())
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				var key, val interface{}
+				if key, err = singularToJSON(fd.MapKey(), k.Value()); err != nil {
+					return false
+				}
+				if val, err = singularToJSON(fd.MapValue(), mv); err != nil {
+					return false
+				}
+				entries = append(entries, map[string]interface{}{"key": key, "value": val})
+				return true
+			})
+			value = entries
+		default:
+			value, err = singularToJSON(fd, v)
+		}
+		if err != nil {
+			return false
+		}
+		obj[string(fd.Name())] = value
+		return true
+	})
+	return obj, err
+}
+
+func singularToJSON(fd protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), nil
+		}
+		return int32(v.Enum()), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint(), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), nil
+	case protoreflect.StringKind:
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToJSON(v.Message())
+	}
+	return nil, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+func jsonToMessage(obj map[string]interface{}, m protoreflect.Message) error {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		return jsonToAny(obj, a)
+	}
+
+	fields := m.Descriptor().Fields()
+	for name, raw := range obj {
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+		if fd == nil || raw == nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			values, ok := raw.([]interface{})
+			if !ok {
+				return fmt.Errorf("field %s: expected list", name)
+			}
+			list := m.Mutable(fd).List()
+			for _, r := range values {
+				v, err := singularFromJSON(fd, r, list.NewElement)
+				if err != nil {
+					return fmt.Errorf("field %s: %v", name, err)
+				}
+				list.Append(v)
+			}
+		case fd.IsMap():
+			if err := jsonToMap(fd, raw, m.Mutable(fd).Map()); err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			child, ok := raw.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("field %s: expected object", name)
+			}
+			if err := jsonToMessage(child, m.Mutable(fd).Message()); err != nil {
+				return err
+			}
+		default:
+			v, err := singularFromJSON(fd, raw, nil)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", name, err)
+			}
+			m.Set(fd, v)
+		}
+	}
+
+	// raw_data_hex carries the exact bytes the node hashed and signed
+	if tx, ok := m.Interface().(*core.Transaction); ok {
+		if rawHex, ok := obj["raw_data_hex"].(string); ok && len(rawHex) > 0 {
+			rawBytes, err := hex.DecodeString(rawHex)
+			if err != nil {
+				return fmt.Errorf("raw_data_hex: %v", err)
+			}
+			tx.RawData = new(core.TransactionRaw)
+			if err := proto.Unmarshal(rawBytes, tx.RawData); err != nil {
+				return fmt.Errorf("raw_data_hex: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func jsonToAny(obj map[string]interface{}, a *anypb.Any) error {
+	typeURL, _ := obj["type_url"].(string)
+	a.TypeUrl = typeURL
+	switch value := obj["value"].(type) {
+	case string:
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("any value: %v", err)
+		}
+		a.Value = b
+	case map[string]interface{}:
+		mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+		if err != nil {
+			return fmt.Errorf("any type %s: %v", typeURL, err)
+		}
+		inner := mt.New()
+		if err := jsonToMessage(value, inner); err != nil {
+			return err
+		}
+		b, err := proto.Marshal(inner.Interface())
+		if err != nil {
+			return err
+		}
+		a.Value = b
+	}
+	return nil
+}
+
+func jsonToMap(fd protoreflect.FieldDescriptor, raw interface{}, mp protoreflect.Map) error {
+	set := func(rk, rv interface{}) error {
+		k, err := singularFromJSON(fd.MapKey(), rk, nil)
+		if err != nil {
+			return err
+		}
+		v, err := singularFromJSON(fd.MapValue(), rv, mp.NewValue)
+		if err != nil {
+			return err
+		}
+		mp.Set(k.MapKey(), v)
+		return nil
+	}
+	switch entries := raw.(type) {
+	case []interface{}:
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("expected key/value object")
+			}
+			if err := set(entry["key"], entry["value"]); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for k, v := range entries {
+			if err := set(k, v); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("expected map")
+	}
+	return nil
+}
+
+func singularFromJSON(fd protoreflect.FieldDescriptor, raw interface{},
+	newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	text := fmt.Sprint(raw)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(text)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(text)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(text, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(text, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(text, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(text, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(text, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(text), nil
+	case protoreflect.BytesKind:
+		b, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("expected hex bytes: %v", err)
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		child, ok := raw.(map[string]interface{})
+		if !ok || newMessage == nil {
+			return protoreflect.Value{}, fmt.Errorf("expected object")
+		}
+		v := newMessage()
+		return v, jsonToMessage(child, v.Message())
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
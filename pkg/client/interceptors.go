@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyHeader metadata key used by TronGrid to identify API keys
+const APIKeyHeader = "TRON-PRO-API-KEY"
+
+// withAPIKey append API key to outgoing metadata if set
+func (g *Client) withAPIKey(ctx context.Context) context.Context {
+	if len(g.apiKey) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, APIKeyHeader, g.apiKey)
+}
+
+// apiKeyUnaryInterceptor inject API key on unary calls
+func (g *Client) apiKeyUnaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(g.withAPIKey(ctx), method, req, reply, cc, opts...)
+}
+
+// apiKeyStreamInterceptor inject API key on stream calls
+func (g *Client) apiKeyStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc,
+	cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(g.withAPIKey(ctx), desc, cc, method, opts...)
+}
+
+// unaryInterceptors applied on top of node connections, outermost first
+func (g *Client) unaryInterceptors() []grpc.UnaryClientInterceptor {
+	return []grpc.UnaryClientInterceptor{
+		g.cassetteUnaryInterceptor,
+		g.retryUnaryInterceptor,
+		g.rateLimitUnaryInterceptor,
+	}
+}
+
+// interceptedConn run client interceptors above the node connection so
+// retries span every node of a pool
+type interceptedConn struct {
+	cc           grpc.ClientConnInterface
+	conn         *grpc.ClientConn
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+// intercept wrap connection with client interceptors, extra interceptors
+// run last, right before the transport
+func (g *Client) intercept(cc grpc.ClientConnInterface, extra ...grpc.UnaryClientInterceptor) grpc.ClientConnInterface {
+	conn, _ := cc.(*grpc.ClientConn)
+	return &interceptedConn{
+		cc:           cc,
+		conn:         conn,
+		interceptors: append(g.unaryInterceptors(), extra...),
+	}
+}
+
+// Invoke unary call through the interceptor chain
+func (c *interceptedConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	invoker := func(ctx context.Context, method string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.cc.Invoke(ctx, method, req, reply, opts...)
+	}
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		next, interceptor := invoker, c.interceptors[i]
+		invoker = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	return invoker(ctx, method, args, reply, c.conn, opts...)
+}
+
+// NewStream open stream on the node connection
+func (c *interceptedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.cc.NewStream(ctx, desc, method, opts...)
+}
@@ -80,10 +80,10 @@ func (g *Client) Broadcast(ctx context.Context, tx *core.Transaction) (*api.Retu
 		return nil, err
 	}
 	if !result.GetResult() {
-		return result, fmt.Errorf("result error: %s", result.GetMessage())
+		return result, fmt.Errorf("result error: %w", &ResultError{Code: result.GetCode(), Message: string(result.GetMessage())})
 	}
-	if result.GetCode() != api.Return_SUCCESS {
-		return result, fmt.Errorf("result error(%s): %s", result.GetCode(), result.GetMessage())
+	if err := resultError(result); err != nil {
+		return result, fmt.Errorf("result error(%s): %w", result.GetCode(), err)
 	}
 	return result, nil
 }
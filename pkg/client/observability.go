@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultLatencyBuckets upper bounds in seconds of the latency histogram
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Observability instruments every call a client makes
+type Observability struct {
+	// Metrics records per RPC counters and latency histograms, nil disables
+	Metrics *Metrics
+	// Tracer creates one span per RPC, nil disables
+	Tracer trace.Tracer
+}
+
+type observabilityOption struct {
+	grpc.EmptyDialOption
+	obs *Observability
+}
+
+// WithObservability Start option instrumenting calls on every node,
+// including pool members and HTTP transports
+func WithObservability(obs *Observability) grpc.DialOption {
+	return observabilityOption{obs: obs}
+}
+
+// observabilityFrom extract observability set on Start options
+func observabilityFrom(opts []grpc.DialOption) *Observability {
+	for _, opt := range opts {
+		if o, ok := opt.(observabilityOption); ok {
+			return o.obs
+		}
+	}
+	return nil
+}
+
+// observeUnaryInterceptor record metrics, spans and debug logs for each call
+// sent to node; an empty node uses the connection target
+func (g *Client) observeUnaryInterceptor(node string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		target := node
+		if len(target) == 0 && cc != nil {
+			target = cc.Target()
+		}
+		obs := g.observability
+
+		var span trace.Span
+		if obs != nil && obs.Tracer != nil {
+			ctx, span = obs.Tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("rpc.system", "grpc"),
+					attribute.String("rpc.method", path.Base(method)),
+					attribute.String("rpc.service", path.Dir(strings.TrimPrefix(method, "/"))),
+					attribute.String("server.address", target),
+				),
+			)
+		}
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		elapsed := time.Since(start)
+		code := status.Code(err)
+
+		if span != nil {
+			span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelcodes.Error, code.String())
+			}
+			span.End()
+		}
+		if obs != nil && obs.Metrics != nil {
+			obs.Metrics.Observe(path.Base(method), target, code.String(), elapsed)
+		}
+		if common.DebugGRPC {
+			logCall(method, target, req, reply, elapsed, err)
+		}
+		return err
+	}
+}
+
+// logCall structured request/response log used when DebugGRPC is set
+func logCall(method, target string, req, reply interface{}, elapsed time.Duration, err error) {
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("node", target),
+		zap.Duration("latency", elapsed),
+		zap.String("code", status.Code(err).String()),
+		zap.String("request", protoText(req)),
+	}
+	if err != nil {
+		zap.L().Debug("grpc call failed", append(fields, zap.Error(err))...)
+		return
+	}
+	zap.L().Debug("grpc call", append(fields, zap.String("response", protoText(reply)))...)
+}
+
+func protoText(v interface{}) string {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.MarshalOptions{}.Format(m)
+	}
+	return fmt.Sprint(v)
+}
+
+type metricKey struct {
+	method, node, code string
+}
+
+type latencyKey struct {
+	method, node string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Metrics per RPC counters and latency histograms with Prometheus text
+// exposition. It implements http.Handler to be mounted as /metrics.
+type Metrics struct {
+	// Namespace prefix of every metric name
+	Namespace string
+	buckets   []float64
+	mu        sync.Mutex
+	requests  map[metricKey]uint64
+	latency   map[latencyKey]*histogram
+}
+
+// NewMetrics create metrics with latency buckets in seconds,
+// DefaultLatencyBuckets when none
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		Namespace: "tron_client",
+		buckets:   buckets,
+		requests:  make(map[metricKey]uint64),
+		latency:   make(map[latencyKey]*histogram),
+	}
+}
+
+// Observe record one call
+func (m *Metrics) Observe(method, node, code string, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[metricKey{method, node, code}]++
+
+	lk := latencyKey{method, node}
+	h, ok := m.latency[lk]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latency[lk] = h
+	}
+	seconds := elapsed.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Requests return calls recorded for method, node and code
+func (m *Metrics) Requests(method, node, code string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[metricKey{method, node, code}]
+}
+
+// WritePrometheus write metrics in Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	requests := m.Namespace + "_requests_total"
+	fmt.Fprintf(&b, "# HELP %s Total RPCs sent by method, node and status code.\n", requests)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", requests)
+	keys := make([]metricKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s{method=%q,node=%q,code=%q} %d\n", requests, k.method, k.node, k.code, m.requests[k])
+	}
+
+	duration := m.Namespace + "_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s RPC latency by method and node.\n", duration)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", duration)
+	lkeys := make([]latencyKey, 0, len(m.latency))
+	for k := range m.latency {
+		lkeys = append(lkeys, k)
+	}
+	sort.Slice(lkeys, func(i, j int) bool {
+		return fmt.Sprint(lkeys[i]) < fmt.Sprint(lkeys[j])
+	})
+	for _, k := range lkeys {
+		h := m.latency[k]
+		for i, le := range m.buckets {
+			fmt.Fprintf(&b, "%s_bucket{method=%q,node=%q,le=\"%g\"} %d\n", duration, k.method, k.node, le, h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{method=%q,node=%q,le=\"+Inf\"} %d\n", duration, k.method, k.node, h.count)
+		fmt.Fprintf(&b, "%s_sum{method=%q,node=%q} %g\n", duration, k.method, k.node, h.sum)
+		fmt.Fprintf(&b, "%s_count{method=%q,node=%q} %d\n", duration, k.method, k.node, h.count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ServeHTTP expose metrics for Prometheus scraping
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
@@ -0,0 +1,67 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestObservability(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	api.RegisterWalletServer(srv, &apiKeyServer{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	metrics := client.NewMetrics()
+
+	c := client.New("passthrough:///bufnet")
+	require.Nil(t, c.Start(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		client.WithObservability(&client.Observability{
+			Metrics: metrics,
+			Tracer:  provider.Tracer("test"),
+		}),
+	))
+	defer c.Stop()
+
+	_, err := c.GetNowBlock(context.Background())
+	require.Nil(t, err)
+	_, err = c.GetNodeInfo(context.Background())
+	require.NotNil(t, err)
+
+	require.Equal(t, uint64(1), metrics.Requests("GetNowBlock2", "passthrough:///bufnet", codes.OK.String()))
+	require.Equal(t, uint64(1), metrics.Requests("GetNodeInfo", "passthrough:///bufnet", codes.Unimplemented.String()))
+
+	var out bytes.Buffer
+	require.Nil(t, metrics.WritePrometheus(&out))
+	require.Contains(t, out.String(),
+		`tron_client_requests_total{method="GetNowBlock2",node="passthrough:///bufnet",code="OK"} 1`)
+	require.Contains(t, out.String(),
+		`tron_client_request_duration_seconds_count{method="GetNodeInfo",node="passthrough:///bufnet"} 1`)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "protocol.Wallet/GetNowBlock2", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("server.address", "passthrough:///bufnet"))
+	require.Equal(t, otelcodes.Error, spans[1].Status().Code)
+	require.Contains(t, spans[1].Attributes(), attribute.Int("rpc.grpc.status_code", int(codes.Unimplemented)))
+}
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPoolCheckInterval = 10 * time.Second
+	defaultPoolCheckTimeout  = 5 * time.Second
+	defaultPoolMaxBlockLag   = 5
+)
+
+// PoolNode health snapshot of a pool endpoint
+type PoolNode struct {
+	Address   string
+	Healthy   bool
+	Height    int64
+	Latency   time.Duration
+	LastCheck time.Time
+	LastError error
+}
+
+type poolNode struct {
+	PoolNode
+	conn *grpc.ClientConn
+}
+
+// Pool keeps connections to several full nodes and routes every call to the
+// healthiest one, failing over to the next node on transport errors.
+// Pool implements grpc.ClientConnInterface so it can back api.WalletClient.
+type Pool struct {
+	// CheckInterval between health probes
+	CheckInterval time.Duration
+	// CheckTimeout for a single node probe
+	CheckTimeout time.Duration
+	// MaxBlockLag blocks a node can trail the best node before being demoted
+	MaxBlockLag int64
+
+	addresses []string
+	mu        sync.RWMutex
+	nodes     []*poolNode
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewPool create pool for the given node addresses
+func NewPool(addresses []string, options ...func(*Pool)) *Pool {
+	p := &Pool{
+		CheckInterval: defaultPoolCheckInterval,
+		CheckTimeout:  defaultPoolCheckTimeout,
+		MaxBlockLag:   defaultPoolMaxBlockLag,
+		addresses:     addresses,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// NewWithPool create grpc controller backed by a node pool
+func NewWithPool(pool *Pool) *Client {
+	client := &Client{
+		pool: pool,
+	}
+	if len(pool.addresses) > 0 {
+		client.Address = pool.addresses[0]
+	}
+	return client
+}
+
+// start dial every node and launch health checks
+func (p *Pool) start(opts ...grpc.DialOption) error {
+	if len(p.addresses) == 0 {
+		return fmt.Errorf("pool: no node address")
+	}
+	nodes := make([]*poolNode, 0, len(p.addresses))
+	for _, addr := range p.addresses {
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			for _, n := range nodes {
+				n.conn.Close()
+			}
+			return fmt.Errorf("pool: connecting %s: %v", addr, err)
+		}
+		nodes = append(nodes, &poolNode{
+			PoolNode: PoolNode{Address: addr, Healthy: true},
+			conn:     conn,
+		})
+	}
+
+	p.mu.Lock()
+	p.nodes = nodes
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	p.mu.Unlock()
+
+	p.Check(context.Background())
+	go p.loop(p.stop, p.done)
+	return nil
+}
+
+// close stop health checks and all connections
+func (p *Pool) close() {
+	p.mu.Lock()
+	stop, done, nodes := p.stop, p.done, p.nodes
+	p.stop, p.done, p.nodes = nil, nil, nil
+	p.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	for _, n := range nodes {
+		n.conn.Close()
+	}
+}
+
+func (p *Pool) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(p.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			p.Check(context.Background())
+		}
+	}
+}
+
+// Check probe every node with GetNowBlock and re-rank them
+func (p *Pool) Check(ctx context.Context) {
+	nodes := p.ranked()
+
+	type probe struct {
+		height  int64
+		latency time.Duration
+		err     error
+	}
+	results := make([]probe, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *poolNode) {
+			defer wg.Done()
+			pctx, cancel := context.WithTimeout(ctx, p.CheckTimeout)
+			defer cancel()
+			start := time.Now()
+			block, err := api.NewWalletClient(n.conn).GetNowBlock2(pctx, new(api.EmptyMessage))
+			results[i] = probe{latency: time.Since(start), err: err}
+			if err == nil {
+				results[i].height = block.GetBlockHeader().GetRawData().GetNumber()
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for i, n := range nodes {
+		n.LastCheck = now
+		n.LastError = results[i].err
+		n.Healthy = results[i].err == nil
+		if n.Healthy {
+			n.Height = results[i].height
+			n.Latency = results[i].latency
+		} else {
+			zap.L().Warn("pool node probe failed", zap.String("address", n.Address), zap.Error(n.LastError))
+		}
+	}
+	p.rank()
+}
+
+// rank sort nodes from healthiest to worst, must hold lock
+func (p *Pool) rank() {
+	best := int64(0)
+	for _, n := range p.nodes {
+		if n.Healthy && n.Height > best {
+			best = n.Height
+		}
+	}
+	score := func(n *poolNode) int {
+		switch {
+		case !n.Healthy:
+			return 2
+		case best-n.Height > p.MaxBlockLag:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(p.nodes, func(i, j int) bool {
+		si, sj := score(p.nodes[i]), score(p.nodes[j])
+		if si != sj {
+			return si < sj
+		}
+		if si == 1 {
+			return p.nodes[i].Height > p.nodes[j].Height
+		}
+		return p.nodes[i].Latency < p.nodes[j].Latency
+	})
+}
+
+// Nodes return current health snapshot, best node first
+func (p *Pool) Nodes() []PoolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	list := make([]PoolNode, len(p.nodes))
+	for i, n := range p.nodes {
+		list[i] = n.PoolNode
+	}
+	return list
+}
+
+// ranked return a copy of the current node order
+func (p *Pool) ranked() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*poolNode(nil), p.nodes...)
+}
+
+// markFailed demote node until next successful probe
+func (p *Pool) markFailed(n *poolNode, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n.Healthy = false
+	n.LastError = err
+	p.rank()
+	zap.L().Warn("pool node failed, switching", zap.String("address", n.Address), zap.Error(err))
+}
+
+// readOnly tells if method only reads node state, so a call the node may
+// already have processed can be sent again
+func readOnly(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Estimate", "Scan", "TotalTransaction"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return name == "TriggerConstantContract"
+}
+
+// shouldFailover tells if the error is node related and another node may
+// succeed. A call that timed out or was aborted may have been processed, it
+// only moves to another node when it does not change the node state.
+func shouldFailover(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	case codes.Aborted, codes.DeadlineExceeded:
+		return readOnly(method)
+	}
+	return false
+}
+
+// Invoke unary call on the healthiest node
+func (p *Pool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	nodes := p.ranked()
+	if len(nodes) == 0 {
+		return status.Error(codes.Unavailable, "pool: not started")
+	}
+	var err error
+	for _, n := range nodes {
+		if err = n.conn.Invoke(ctx, method, args, reply, opts...); err == nil {
+			return nil
+		}
+		if !shouldFailover(ctx, method, err) {
+			return err
+		}
+		p.markFailed(n, err)
+	}
+	return err
+}
+
+// NewStream open stream on the healthiest node
+func (p *Pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	nodes := p.ranked()
+	if len(nodes) == 0 {
+		return nil, status.Error(codes.Unavailable, "pool: not started")
+	}
+	var (
+		stream grpc.ClientStream
+		err    error
+	)
+	for _, n := range nodes {
+		if stream, err = n.conn.NewStream(ctx, desc, method, opts...); err == nil {
+			return stream, nil
+		}
+		if !shouldFailover(ctx, method, err) {
+			return nil, err
+		}
+		p.markFailed(n, err)
+	}
+	return nil, err
+}
@@ -0,0 +1,123 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type poolTestServer struct {
+	api.UnimplementedWalletServer
+	height int64
+	down   atomic.Bool
+	abort  atomic.Bool
+	calls  atomic.Int32
+	sent   atomic.Int32
+}
+
+func (s *poolTestServer) GetNowBlock2(context.Context, *api.EmptyMessage) (*api.BlockExtention, error) {
+	if s.down.Load() {
+		return nil, status.Error(codes.Unavailable, "node down")
+	}
+	return &api.BlockExtention{
+		BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: s.height}},
+	}, nil
+}
+
+func (s *poolTestServer) GetNodeInfo(context.Context, *api.EmptyMessage) (*core.NodeInfo, error) {
+	s.calls.Add(1)
+	if s.down.Load() {
+		return nil, status.Error(codes.Unavailable, "node down")
+	} else if s.abort.Load() {
+		return nil, status.Error(codes.Aborted, "node aborted")
+	}
+	return &core.NodeInfo{}, nil
+}
+
+func (s *poolTestServer) BroadcastTransaction(context.Context, *core.Transaction) (*api.Return, error) {
+	s.sent.Add(1)
+	if s.abort.Load() {
+		return nil, status.Error(codes.Aborted, "node aborted")
+	}
+	return &api.Return{Result: true}, nil
+}
+
+func startPoolTestServers(t *testing.T, servers map[string]*poolTestServer) grpc.DialOption {
+	listeners := make(map[string]*bufconn.Listener)
+	for name, s := range servers {
+		lis := bufconn.Listen(1024 * 1024)
+		srv := grpc.NewServer()
+		api.RegisterWalletServer(srv, s)
+		go srv.Serve(lis)
+		t.Cleanup(srv.Stop)
+		listeners[name] = lis
+	}
+	return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return listeners[addr].DialContext(ctx)
+	})
+}
+
+func TestPoolFailover(t *testing.T) {
+	servers := map[string]*poolTestServer{
+		"behind": {height: 90},
+		"best":   {height: 100},
+	}
+	dialer := startPoolTestServers(t, servers)
+
+	c := client.NewWithPool(client.NewPool([]string{"passthrough:///behind", "passthrough:///best"}))
+	require.Nil(t, c.Start(grpc.WithTransportCredentials(insecure.NewCredentials()), dialer))
+	defer c.Stop()
+
+	require.Equal(t, "passthrough:///best", c.Pool().Nodes()[0].Address)
+
+	_, err := c.GetNodeInfo(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int32(1), servers["best"].calls.Load())
+	require.Equal(t, int32(0), servers["behind"].calls.Load())
+
+	servers["best"].down.Store(true)
+	_, err = c.GetNodeInfo(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int32(1), servers["behind"].calls.Load())
+	require.Equal(t, "passthrough:///behind", c.Pool().Nodes()[0].Address)
+	require.False(t, c.Pool().Nodes()[1].Healthy)
+
+	servers["best"].down.Store(false)
+	c.Pool().Check(context.Background())
+	require.Equal(t, "passthrough:///best", c.Pool().Nodes()[0].Address)
+}
+
+func TestPoolFailoverReadOnly(t *testing.T) {
+	servers := map[string]*poolTestServer{
+		"behind": {height: 90},
+		"best":   {height: 100},
+	}
+	dialer := startPoolTestServers(t, servers)
+
+	c := client.NewWithPool(client.NewPool([]string{"passthrough:///behind", "passthrough:///best"}))
+	require.Nil(t, c.Start(grpc.WithTransportCredentials(insecure.NewCredentials()), dialer))
+	defer c.Stop()
+
+	// the aborted broadcast may have been accepted, it is not sent again
+	servers["best"].abort.Store(true)
+	_, err := c.Broadcast(context.Background(), &core.Transaction{})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	require.Equal(t, int32(1), servers["best"].sent.Load())
+	require.Equal(t, int32(0), servers["behind"].sent.Load())
+
+	// reads move to another node
+	_, err = c.GetNodeInfo(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int32(1), servers["behind"].calls.Load())
+}
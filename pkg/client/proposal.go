@@ -33,8 +33,8 @@ func (g *Client) ProposalCreate(ctx context.Context, from string, parameters map
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -58,8 +58,8 @@ func (g *Client) ProposalApprove(ctx context.Context, from string, id int64, con
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -81,8 +81,8 @@ func (g *Client) ProposalWithdraw(ctx context.Context, from string, id int64) (*
 	if proto.Size(tx) == 0 {
 		return nil, fmt.Errorf("bad transaction")
 	}
-	if tx.GetResult().GetCode() != 0 {
-		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	if err := resultError(tx.GetResult()); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"math"
+	"path"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// RateLimit token bucket budget
+type RateLimit struct {
+	// QPS sustained calls per second
+	QPS float64
+	// Burst calls allowed at once, defaults to QPS rounded up
+	Burst int
+}
+
+// TronGridFreeLimit budget of a TronGrid free tier API key
+var TronGridFreeLimit = RateLimit{QPS: 15, Burst: 15}
+
+// RateLimiter blocks callers until both the global and the per method
+// budgets have capacity for the call
+type RateLimiter struct {
+	global  *tokenBucket
+	methods map[string]*tokenBucket
+}
+
+// NewRateLimiter create limiter, methods are keyed by RPC name
+// (e.g. "TriggerConstantContract") and may be nil. A zero global limit
+// only applies the method budgets.
+func NewRateLimiter(global RateLimit, methods map[string]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		methods: make(map[string]*tokenBucket, len(methods)),
+	}
+	if global.QPS > 0 {
+		l.global = newTokenBucket(global)
+	}
+	for name, limit := range methods {
+		if limit.QPS > 0 {
+			l.methods[name] = newTokenBucket(limit)
+		}
+	}
+	return l
+}
+
+// Wait block until method can be called or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	buckets := make([]*tokenBucket, 0, 2)
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+	if b, ok := l.methods[path.Base(method)]; ok {
+		buckets = append(buckets, b)
+	}
+
+	now := time.Now()
+	wait := time.Duration(0)
+	for _, b := range buckets {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		for _, b := range buckets {
+			b.cancel()
+		}
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SetRateLimiter limit calls made by the client, nil disables limiting
+func (g *Client) SetRateLimiter(limiter *RateLimiter) {
+	g.limiter = limiter
+}
+
+// rateLimitUnaryInterceptor wait for limiter capacity before each attempt
+func (g *Client) rateLimitUnaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if g.limiter != nil {
+		if err := g.limiter.Wait(ctx, method); err != nil {
+			return err
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(math.Ceil(limit.QPS), 1)
+	}
+	return &tokenBucket{
+		rate:   limit.QPS,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve take a token, return how long to wait until it is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel give back a reserved token that was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
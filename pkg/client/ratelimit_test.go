@@ -0,0 +1,38 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterGlobal(t *testing.T) {
+	limiter := client.NewRateLimiter(client.RateLimit{QPS: 20, Burst: 1}, nil)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.Nil(t, limiter.Wait(context.Background(), "/protocol.Wallet/GetNowBlock2"))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestRateLimiterMethod(t *testing.T) {
+	limiter := client.NewRateLimiter(client.RateLimit{}, map[string]client.RateLimit{
+		"TriggerConstantContract": {QPS: 1, Burst: 1},
+	})
+	require.Nil(t, limiter.Wait(context.Background(), "/protocol.Wallet/TriggerConstantContract"))
+
+	// other methods are not limited
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		require.Nil(t, limiter.Wait(context.Background(), "/protocol.Wallet/GetNowBlock2"))
+	}
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := limiter.Wait(ctx, "/protocol.Wallet/TriggerConstantContract")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
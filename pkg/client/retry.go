@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls how transient gRPC failures are retried
+type RetryPolicy struct {
+	// MaxAttempts including the first call, values < 2 disable retries
+	MaxAttempts int
+	// InitialBackoff before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff
+	MaxBackoff time.Duration
+	// Multiplier applied to the backoff after each attempt
+	Multiplier float64
+	// Jitter randomizes each backoff by +/- this fraction (0 to 1)
+	Jitter float64
+	// Codes considered transient
+	Codes []codes.Code
+}
+
+var (
+	// DefaultRetryPolicy retries unavailable and overloaded nodes
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Codes: []codes.Code{
+			codes.Unavailable,
+			codes.DeadlineExceeded,
+			codes.ResourceExhausted,
+			codes.Aborted,
+		},
+	}
+	// NoRetry disables retries, use with WithRetryPolicy for a single call
+	NoRetry = RetryPolicy{MaxAttempts: 1}
+)
+
+// broadcastRetryCodes node refused the transaction without processing it
+var broadcastRetryCodes = map[api.ReturnResponseCode]bool{
+	api.Return_SERVER_BUSY:                     true,
+	api.Return_NO_CONNECTION:                   true,
+	api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION: true,
+	api.Return_BLOCK_UNSOLIDIFIED:              true,
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy override client retry policy for calls using ctx
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// SetRetryPolicy set default retry policy, nil disables retries
+func (g *Client) SetRetryPolicy(policy *RetryPolicy) {
+	g.retry = policy
+}
+
+// policy for the call, context override takes precedence
+func (g *Client) retryPolicy(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return &policy
+	}
+	return g.retry
+}
+
+// Retryable tells if err has a transient code for this policy
+func (p *RetryPolicy) Retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff return wait duration before retry number attempt (starting at 1)
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+		if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+			backoff = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// isBroadcast tells if method submits a transaction to the network
+func isBroadcast(method string) bool {
+	return strings.HasSuffix(method, "/BroadcastTransaction")
+}
+
+// retryUnaryInterceptor retry transient failures according to the policy.
+// Every wallet read and transaction builder is idempotent. Broadcasting the
+// same signed transaction twice is safe too since the node rejects the copy
+// with DUP_TRANSACTION_ERROR, which is reported as success on a retry.
+func (g *Client) retryUnaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	policy := g.retryPolicy(ctx)
+	if policy == nil || policy.MaxAttempts < 2 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	broadcast := isBroadcast(method)
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		retry := err != nil && policy.Retryable(err)
+		if result, ok := reply.(*api.Return); ok && broadcast && err == nil {
+			if attempt > 1 && result.GetCode() == api.Return_DUP_TRANSACTION_ERROR {
+				result.Result = true
+				result.Code = api.Return_SUCCESS
+			}
+			retry = broadcastRetryCodes[result.GetCode()]
+		}
+		if !retry || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		backoff := policy.Backoff(attempt)
+		zap.L().Debug("retrying call",
+			zap.String("method", method),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
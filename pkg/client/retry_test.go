@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type flakyServer struct {
+	api.UnimplementedWalletServer
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *flakyServer) GetNodeInfo(context.Context, *api.EmptyMessage) (*core.NodeInfo, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &core.NodeInfo{}, nil
+}
+
+func (s *flakyServer) BroadcastTransaction(context.Context, *core.Transaction) (*api.Return, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	// first attempt reached the node before the connection failed
+	return &api.Return{Code: api.Return_DUP_TRANSACTION_ERROR, Message: []byte("dup transaction")}, nil
+}
+
+func startFlakyClient(t *testing.T, failures int32) (*client.Client, *flakyServer) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	flaky := &flakyServer{failures: failures}
+	api.RegisterWalletServer(srv, flaky)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	c := client.New("passthrough:///bufnet")
+	policy := client.DefaultRetryPolicy
+	policy.InitialBackoff = time.Millisecond
+	c.SetRetryPolicy(&policy)
+	require.Nil(t, c.Start(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	))
+	t.Cleanup(c.Stop)
+	return c, flaky
+}
+
+func TestRetryTransient(t *testing.T) {
+	c, flaky := startFlakyClient(t, 2)
+	_, err := c.GetNodeInfo(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, int32(3), flaky.calls.Load())
+}
+
+func TestRetryExhausted(t *testing.T) {
+	c, flaky := startFlakyClient(t, 10)
+	_, err := c.GetNodeInfo(context.Background())
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(client.DefaultRetryPolicy.MaxAttempts), flaky.calls.Load())
+}
+
+func TestRetryPerCallOverride(t *testing.T) {
+	c, flaky := startFlakyClient(t, 1)
+	ctx := client.WithRetryPolicy(context.Background(), client.NoRetry)
+	_, err := c.GetNodeInfo(ctx)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(1), flaky.calls.Load())
+}
+
+func TestRetryBroadcastDuplicate(t *testing.T) {
+	c, flaky := startFlakyClient(t, 1)
+	result, err := c.Broadcast(context.Background(), &core.Transaction{})
+	require.Nil(t, err)
+	require.Equal(t, api.Return_SUCCESS, result.GetCode())
+	require.Equal(t, int32(2), flaky.calls.Load())
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+)
+
+const (
+	defaultScanChunkSize = 100
+	defaultScanWorkers   = 4
+)
+
+// ScannedBlock block with the receipts of its transactions
+type ScannedBlock struct {
+	Block *api.BlockExtention
+	// Infos in transaction order, empty for blocks without transactions
+	Infos []*core.TransactionInfo
+}
+
+// Scanner settings of Client.Scan
+type Scanner struct {
+	// ChunkSize blocks per GetBlockByLimitNext2 call, 100 at most
+	ChunkSize int64
+	// Workers chunks fetched in parallel
+	Workers int
+	// Checkpoint file keeping the last height handled, scans resume after it
+	Checkpoint string
+}
+
+type scanChunk struct {
+	start, end int64
+	result     chan scanResult
+}
+
+type scanResult struct {
+	blocks []*ScannedBlock
+	err    error
+}
+
+// Scan call handler for every block from from to to, both included, in
+// block order. Chunks of blocks and their receipts are fetched by parallel
+// workers through the client, so retry and rate limits apply. With a
+// Checkpoint file the last height handled is saved after every chunk and
+// a later scan resumes after it. The scan stops at the first error.
+func (g *Client) Scan(ctx context.Context, from, to int64, handler func(*ScannedBlock) error, options ...func(*Scanner)) error {
+	s := &Scanner{
+		ChunkSize: defaultScanChunkSize,
+		Workers:   defaultScanWorkers,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.ChunkSize <= 0 || s.ChunkSize > maxBlockLimit || s.Workers <= 0 {
+		return fmt.Errorf("scan: invalid chunk size %d or workers %d", s.ChunkSize, s.Workers)
+	}
+	if len(s.Checkpoint) > 0 {
+		height, ok, err := readScanCheckpoint(s.Checkpoint)
+		if err != nil {
+			return err
+		}
+		if ok && height >= from {
+			from = height + 1
+		}
+	}
+	if from > to {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	jobs := make(chan *scanChunk)
+	// bounds how far workers run ahead of the handler
+	pending := make(chan *scanChunk, s.Workers)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		defer close(pending)
+		for start := from; start <= to; start += s.ChunkSize {
+			end := start + s.ChunkSize
+			if end > to+1 {
+				end = to + 1
+			}
+			chunk := &scanChunk{start: start, end: end, result: make(chan scanResult, 1)}
+			select {
+			case pending <- chunk:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for i := 0; i < s.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				blocks, err := g.scanChunk(ctx, chunk.start, chunk.end)
+				chunk.result <- scanResult{blocks: blocks, err: err}
+			}
+		}()
+	}
+
+	for chunk := range pending {
+		var result scanResult
+		select {
+		case result = <-chunk.result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if result.err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return result.err
+		}
+		for _, block := range result.blocks {
+			if err := handler(block); err != nil {
+				return err
+			}
+		}
+		if len(s.Checkpoint) > 0 {
+			if err := writeScanCheckpoint(s.Checkpoint, chunk.end-1); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// scanChunk fetch blocks [start, end) with their receipts
+func (g *Client) scanChunk(ctx context.Context, start, end int64) ([]*ScannedBlock, error) {
+	list, err := g.GetBlockByLimitNext(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("scan: blocks %d-%d: %v", start, end-1, err)
+	}
+	if int64(len(list.GetBlock())) != end-start {
+		return nil, fmt.Errorf("scan: blocks %d-%d: node returned %d blocks", start, end-1, len(list.GetBlock()))
+	}
+	blocks := make([]*ScannedBlock, 0, len(list.GetBlock()))
+	for i, block := range list.GetBlock() {
+		num := block.GetBlockHeader().GetRawData().GetNumber()
+		if num != start+int64(i) {
+			return nil, fmt.Errorf("scan: expected block %d, got %d", start+int64(i), num)
+		}
+		scanned := &ScannedBlock{Block: block}
+		if len(block.GetTransactions()) > 0 {
+			infos, err := g.GetBlockInfoByNum(ctx, num)
+			if err != nil {
+				return nil, fmt.Errorf("scan: %v", err)
+			}
+			scanned.Infos = infos.GetTransactionInfo()
+		}
+		blocks = append(blocks, scanned)
+	}
+	return blocks, nil
+}
+
+func readScanCheckpoint(path string) (int64, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("scan checkpoint: %v", err)
+	}
+	height, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("scan checkpoint %s: %v", path, err)
+	}
+	return height, true, nil
+}
+
+// writeScanCheckpoint replace the checkpoint atomically so a crash leaves
+// the previous height
+func writeScanCheckpoint(path string, height int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("scan checkpoint: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(strconv.FormatInt(height, 10) + "\n"); err != nil {
+		tmp.Close()
+		return fmt.Errorf("scan checkpoint: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("scan checkpoint: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("scan checkpoint: %v", err)
+	}
+	return nil
+}
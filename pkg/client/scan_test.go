@@ -0,0 +1,109 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+func newScanNode(t *testing.T) (*clienttest.Node, *client.Client) {
+	node, c := newSubscriptionNode(t, 40)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	node.Fund(acct.Address.String(), 10_000_000)
+
+	tx, err := c.Transfer(context.Background(), acct.Address.String(), accountAddress, 1_000_000)
+	require.Nil(t, err)
+	_, err = ks.SignTx(acct, tx.Transaction)
+	require.Nil(t, err)
+	_, err = c.Broadcast(context.Background(), tx.Transaction)
+	require.Nil(t, err)
+	for i := 0; i < 60; i++ {
+		node.ProduceBlock()
+	}
+	return node, c
+}
+
+func small(s *client.Scanner) {
+	s.ChunkSize = 7
+	s.Workers = 3
+}
+
+func TestScan(t *testing.T) {
+	_, c := newScanNode(t)
+	var scanned []int64
+	err := c.Scan(context.Background(), 5, 95, func(b *client.ScannedBlock) error {
+		num := b.Block.BlockHeader.RawData.Number
+		scanned = append(scanned, num)
+		if num == 41 {
+			require.Len(t, b.Block.Transactions, 1)
+			require.Len(t, b.Infos, 1)
+			require.Equal(t, b.Block.Transactions[0].Txid, b.Infos[0].Id)
+		} else {
+			require.Empty(t, b.Infos)
+		}
+		return nil
+	}, small)
+	require.Nil(t, err)
+	require.Len(t, scanned, 91)
+	for i, num := range scanned {
+		require.Equal(t, int64(5+i), num)
+	}
+
+	err = c.Scan(context.Background(), 90, 120, func(*client.ScannedBlock) error { return nil }, small)
+	require.NotNil(t, err, "blocks past the head")
+
+	err = c.Scan(context.Background(), 5, 95, func(*client.ScannedBlock) error { return nil },
+		func(s *client.Scanner) { s.ChunkSize = 101 })
+	require.ErrorContains(t, err, "invalid chunk size 101")
+}
+
+func TestScanCheckpoint(t *testing.T) {
+	_, c := newScanNode(t)
+	checkpoint := filepath.Join(t.TempDir(), "scan.checkpoint")
+	options := func(s *client.Scanner) {
+		small(s)
+		s.Checkpoint = checkpoint
+	}
+	crash := errors.New("crash")
+
+	err := c.Scan(context.Background(), 1, 100, func(b *client.ScannedBlock) error {
+		if b.Block.BlockHeader.RawData.Number == 50 {
+			return crash
+		}
+		return nil
+	}, options)
+	require.Equal(t, crash, err)
+	data, err := os.ReadFile(checkpoint)
+	require.Nil(t, err)
+	require.Equal(t, "49\n", string(data))
+
+	var first, last int64
+	err = c.Scan(context.Background(), 1, 100, func(b *client.ScannedBlock) error {
+		if first == 0 {
+			first = b.Block.BlockHeader.RawData.Number
+		}
+		last = b.Block.BlockHeader.RawData.Number
+		return nil
+	}, options)
+	require.Nil(t, err)
+	require.Equal(t, int64(50), first)
+	require.Equal(t, int64(100), last)
+
+	called := false
+	err = c.Scan(context.Background(), 1, 100, func(*client.ScannedBlock) error {
+		called = true
+		return nil
+	}, options)
+	require.Nil(t, err)
+	require.False(t, called)
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/abi"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+)
+
+// Simulation outcome of running a call without changing state
+type Simulation struct {
+	Success    bool
+	EnergyUsed int64
+	// Result data returned by the call
+	Result []byte
+	// Message node message of a failed call
+	Message string
+	// Revert decoded revert data of a failed call
+	Revert *abi.Revert
+}
+
+// Simulate run the smart contract call of tx through TriggerConstantContract
+func (g *Client) Simulate(ctx context.Context, tx *core.Transaction) (*Simulation, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("transaction must contain exactly one contract")
+	}
+	contract, err := contracts[0].GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	trigger, ok := contract.(*core.TriggerSmartContract)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a smart contract call", contracts[0].GetType())
+	}
+	return g.SimulateCall(ctx, trigger)
+}
+
+// SimulateCall run a state-changing call through TriggerConstantContract,
+// decoding its revert reason when it fails
+func (g *Client) SimulateCall(ctx context.Context, ct *core.TriggerSmartContract) (*Simulation, error) {
+	ext, err := g.triggerConstantContract(ctx, ct)
+	if err != nil {
+		return nil, err
+	}
+	s := &Simulation{
+		Success:    !callFailed(ext),
+		EnergyUsed: ext.GetEnergyUsed(),
+	}
+	if results := ext.GetConstantResult(); len(results) > 0 {
+		s.Result = results[0]
+	}
+	if s.Success {
+		return s, nil
+	}
+	s.Message = string(ext.GetResult().GetMessage())
+	s.Revert = abi.DecodeRevert(s.Result, g.contractABI(ctx, ct.GetContractAddress()))
+	return s, nil
+}
+
+// callFailed tells if a constant call reverted or failed. java-tron answers
+// them with a SUCCESS result carrying the error message, such as "REVERT
+// opcode executed", and marks the failure in the transaction ret.
+func callFailed(ext *api.TransactionExtention) bool {
+	if ext.GetResult().GetCode() != api.Return_SUCCESS || len(ext.GetResult().GetMessage()) > 0 {
+		return true
+	}
+	for _, ret := range ext.GetTransaction().GetRet() {
+		if ret.GetRet() == core.Transaction_Result_FAILED {
+			return true
+		}
+		switch ret.GetContractRet() {
+		case core.Transaction_Result_DEFAULT, core.Transaction_Result_SUCCESS:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// RevertReason decoded revert data of a failed transaction, nil when it has none
+func (g *Client) RevertReason(ctx context.Context, info *core.TransactionInfo) *abi.Revert {
+	if info.GetResult() != core.TransactionInfo_FAILED || len(info.GetContractResult()) == 0 ||
+		len(info.GetContractResult()[0]) == 0 {
+		return nil
+	}
+	return abi.DecodeRevert(info.GetContractResult()[0], g.contractABI(ctx, info.GetContractAddress()))
+}
+
+// contractABI ABI of a deployed contract, nil when it cannot be fetched as
+// custom errors are then left undecoded
+func (g *Client) contractABI(ctx context.Context, contractAddress []byte) *core.SmartContract_ABI {
+	if len(contractAddress) == 0 {
+		return nil
+	}
+	sm, err := g.Client.GetContract(ctx, &api.BytesMessage{Value: contractAddress})
+	if err != nil {
+		return nil
+	}
+	return sm.GetAbi()
+}
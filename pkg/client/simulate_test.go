@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/abi"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	owner := acct.Address.String()
+	node.Fund(owner, 100_000_000)
+
+	const token = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	node.SetContract(token, &core.SmartContract{Abi: &core.SmartContract_ABI{Entrys: []*core.SmartContract_ABI_Entry{
+		{Type: core.SmartContract_ABI_Entry_Error, Name: "InsufficientBalance", Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Name: "needed", Type: "uint256"},
+		}},
+	}}})
+	node.HandleCall(token, "transfer(address,uint256)", func(call *core.TriggerSmartContract) ([]byte, error) {
+		amount := new(big.Int).SetBytes(call.Data[36:68])
+		if amount.Cmp(big.NewInt(1_000)) > 0 {
+			data, err := abi.Pack("InsufficientBalance(uint256)", []abi.Param{{"uint256": amount.String()}})
+			if err != nil {
+				return nil, err
+			}
+			return nil, &clienttest.Revert{Data: data}
+		}
+		return common.LeftPadBytes([]byte{1}, 32), nil
+	})
+
+	ctx := context.Background()
+	tx, err := c.TRC20Send(ctx, owner, accountAddress, token, big.NewInt(1_000), 10_000_000)
+	require.Nil(t, err)
+	simulation, err := c.Simulate(ctx, tx.Transaction)
+	require.Nil(t, err)
+	require.True(t, simulation.Success)
+	require.Equal(t, int64(14650), simulation.EnergyUsed)
+	require.Equal(t, common.LeftPadBytes([]byte{1}, 32), simulation.Result)
+	require.Nil(t, simulation.Revert)
+
+	tx, err = c.TRC20Send(ctx, owner, accountAddress, token, big.NewInt(5_000), 10_000_000)
+	require.Nil(t, err)
+	simulation, err = c.Simulate(ctx, tx.Transaction)
+	require.Nil(t, err)
+	require.False(t, simulation.Success)
+	require.Equal(t, "InsufficientBalance", simulation.Revert.Name)
+	require.Equal(t, "InsufficientBalance(5000)", simulation.Revert.Reason)
+
+	// java-tron answers a reverted constant call with a SUCCESS result and
+	// a FAILED transaction ret
+	ext, err := c.TriggerConstantContract(ctx, owner, token, "transfer(address,uint256)",
+		`[{"address":"`+accountAddress+`"},{"uint256":"5000"}]`)
+	require.Nil(t, err)
+	require.True(t, ext.Result.Result)
+	require.Equal(t, api.Return_SUCCESS, ext.Result.Code)
+	require.Equal(t, "REVERT opcode executed", string(ext.Result.Message))
+	require.Equal(t, core.Transaction_Result_FAILED, ext.Transaction.Ret[0].Ret)
+	_, err = c.EstimateCost(ctx, tx.Transaction, owner)
+	require.ErrorContains(t, err, "InsufficientBalance(5000)")
+
+	// the receipt of the failed transaction decodes the same way
+	ctrlr := transaction.NewController(c, ks, &acct, tx.Transaction, func(c *transaction.Controller) {
+		c.Behavior.ConfirmationWaitTime = 5
+	})
+	require.Nil(t, ctrlr.ExecuteTransaction(ctx))
+	require.EqualError(t, ctrlr.GetResultError(), "REVERT opcode executed: InsufficientBalance(5000)")
+	revert := c.RevertReason(ctx, ctrlr.Receipt)
+	require.NotNil(t, revert)
+	require.Equal(t, []interface{}{big.NewInt(5_000)}, revert.Args)
+
+	transfer, err := c.Transfer(ctx, owner, accountAddress, 1)
+	require.Nil(t, err)
+	_, err = c.Simulate(ctx, transfer.Transaction)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,381 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+// ErrNoSolidityNode is returned by confirmed reads when no solidity node is set
+var ErrNoSolidityNode = errors.New("solidity node not configured")
+
+// SetSolidityAddress set the solidity node serving confirmed reads, it is
+// dialed on Start with the same options as the full node
+func (g *Client) SetSolidityAddress(address string) {
+	g.SolidityAddress = address
+}
+
+// startSolidity dial solidity node if configured
+func (g *Client) startSolidity() error {
+	if len(g.SolidityAddress) == 0 {
+		return nil
+	}
+	if IsHTTPAddress(g.SolidityAddress) {
+		g.Solidity = api.NewWalletSolidityClient(g.intercept(newHTTPConn(g.SolidityAddress),
+			g.apiKeyUnaryInterceptor, g.observeUnaryInterceptor(g.SolidityAddress)))
+		return nil
+	}
+	conn, err := grpc.NewClient(g.SolidityAddress, g.dialOptions()...)
+	if err != nil {
+		return fmt.Errorf("connecting GRPC Solidity Client: %v", err)
+	}
+	g.SolidityConn = conn
+	g.Solidity = api.NewWalletSolidityClient(g.intercept(conn))
+	return nil
+}
+
+// Confirmed return a client view reading irreversible state from the solidity
+// node. Reads the solidity node does not serve, and every transaction builder,
+// still go to the full node. The view shares connections with g, stop g only.
+func (g *Client) Confirmed() *Client {
+	// created before copying so the view shares the chain parameters cache
+	g.chainParametersCache()
+	confirmed := *g
+	confirmed.Client = &solidityWallet{
+		WalletClient: g.Client,
+		solidity:     g.Solidity,
+	}
+	return &confirmed
+}
+
+// solidityWallet route every call the solidity API supports to the solidity node
+type solidityWallet struct {
+	api.WalletClient
+	solidity api.WalletSolidityClient
+}
+
+func (s *solidityWallet) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAccount(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAccountById(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAccountById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ListWitnesses(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.WitnessList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ListWitnesses(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAssetIssueList(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.AssetIssueList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAssetIssueList(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetPaginatedAssetIssueList(ctx context.Context, in *api.PaginatedMessage, opts ...grpc.CallOption) (*api.AssetIssueList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetPaginatedAssetIssueList(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAssetIssueByName(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.AssetIssueContract, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAssetIssueByName(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAssetIssueListByName(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*api.AssetIssueList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAssetIssueListByName(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAssetIssueById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.AssetIssueContract, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAssetIssueById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetNowBlock(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.Block, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetNowBlock(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetNowBlock2(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBlockByNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*core.Block, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBlockByNum(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBlockByNum2(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetTransactionCountByBlockNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.NumberMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetTransactionCountByBlockNum(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetDelegatedResource(ctx context.Context, in *api.DelegatedResourceMessage, opts ...grpc.CallOption) (*api.DelegatedResourceList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetDelegatedResource(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetDelegatedResourceV2(ctx context.Context, in *api.DelegatedResourceMessage, opts ...grpc.CallOption) (*api.DelegatedResourceList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetDelegatedResourceV2(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetDelegatedResourceAccountIndex(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.DelegatedResourceAccountIndex, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetDelegatedResourceAccountIndex(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetDelegatedResourceAccountIndexV2(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.DelegatedResourceAccountIndex, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetDelegatedResourceAccountIndexV2(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetCanDelegatedMaxSize(ctx context.Context, in *api.CanDelegatedMaxSizeRequestMessage, opts ...grpc.CallOption) (*api.CanDelegatedMaxSizeResponseMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetCanDelegatedMaxSize(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetAvailableUnfreezeCount(ctx context.Context, in *api.GetAvailableUnfreezeCountRequestMessage, opts ...grpc.CallOption) (*api.GetAvailableUnfreezeCountResponseMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetAvailableUnfreezeCount(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetCanWithdrawUnfreezeAmount(ctx context.Context, in *api.CanWithdrawUnfreezeAmountRequestMessage, opts ...grpc.CallOption) (*api.CanWithdrawUnfreezeAmountResponseMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetCanWithdrawUnfreezeAmount(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetExchangeById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.Exchange, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetExchangeById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ListExchanges(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.ExchangeList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ListExchanges(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetTransactionById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.Transaction, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetTransactionById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetTransactionInfoById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMerkleTreeVoucherInfo(ctx context.Context, in *core.OutputPointInfo, opts ...grpc.CallOption) (*core.IncrementalMerkleVoucherInfo, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMerkleTreeVoucherInfo(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ScanNoteByIvk(ctx context.Context, in *api.IvkDecryptParameters, opts ...grpc.CallOption) (*api.DecryptNotes, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ScanNoteByIvk(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ScanAndMarkNoteByIvk(ctx context.Context, in *api.IvkDecryptAndMarkParameters, opts ...grpc.CallOption) (*api.DecryptNotesMarked, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ScanAndMarkNoteByIvk(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ScanNoteByOvk(ctx context.Context, in *api.OvkDecryptParameters, opts ...grpc.CallOption) (*api.DecryptNotes, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ScanNoteByOvk(ctx, in, opts...)
+}
+
+func (s *solidityWallet) IsSpend(ctx context.Context, in *api.NoteParameters, opts ...grpc.CallOption) (*api.SpendResult, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.IsSpend(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ScanShieldedTRC20NotesByIvk(ctx context.Context, in *api.IvkDecryptTRC20Parameters, opts ...grpc.CallOption) (*api.DecryptNotesTRC20, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ScanShieldedTRC20NotesByIvk(ctx, in, opts...)
+}
+
+func (s *solidityWallet) ScanShieldedTRC20NotesByOvk(ctx context.Context, in *api.OvkDecryptTRC20Parameters, opts ...grpc.CallOption) (*api.DecryptNotesTRC20, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.ScanShieldedTRC20NotesByOvk(ctx, in, opts...)
+}
+
+func (s *solidityWallet) IsShieldedTRC20ContractNoteSpent(ctx context.Context, in *api.NfTRC20Parameters, opts ...grpc.CallOption) (*api.NullifierResult, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.IsShieldedTRC20ContractNoteSpent(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetRewardInfo(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*api.NumberMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetRewardInfo(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBrokerageInfo(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*api.NumberMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBrokerageInfo(ctx, in, opts...)
+}
+
+func (s *solidityWallet) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.TriggerConstantContract(ctx, in, opts...)
+}
+
+func (s *solidityWallet) EstimateEnergy(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.EstimateEnergyMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.EstimateEnergy(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.TransactionInfoList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetTransactionInfoByBlockNum(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMarketOrderById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.MarketOrder, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMarketOrderById(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMarketOrderByAccount(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.MarketOrderList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMarketOrderByAccount(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMarketPriceByPair(ctx context.Context, in *core.MarketOrderPair, opts ...grpc.CallOption) (*core.MarketPriceList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMarketPriceByPair(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMarketOrderListByPair(ctx context.Context, in *core.MarketOrderPair, opts ...grpc.CallOption) (*core.MarketOrderList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMarketOrderListByPair(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetMarketPairList(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.MarketOrderPairList, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetMarketPairList(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBurnTrx(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.NumberMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBurnTrx(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBlock(ctx context.Context, in *api.BlockReq, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBlock(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetBandwidthPrices(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.PricesResponseMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetBandwidthPrices(ctx, in, opts...)
+}
+
+func (s *solidityWallet) GetEnergyPrices(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.PricesResponseMessage, error) {
+	if s.solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	return s.solidity.GetEnergyPrices(ctx, in, opts...)
+}
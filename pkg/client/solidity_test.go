@@ -0,0 +1,75 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type balanceWallet struct {
+	api.UnimplementedWalletServer
+	balance int64
+}
+
+func (s *balanceWallet) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	return &core.Account{Address: in.Address, Balance: s.balance}, nil
+}
+
+type balanceSolidity struct {
+	api.UnimplementedWalletSolidityServer
+	balance int64
+}
+
+func (s *balanceSolidity) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	return &core.Account{Address: in.Address, Balance: s.balance}, nil
+}
+
+func TestConfirmedReads(t *testing.T) {
+	listeners := map[string]*bufconn.Listener{
+		"full":     bufconn.Listen(1024 * 1024),
+		"solidity": bufconn.Listen(1024 * 1024),
+	}
+	full := grpc.NewServer()
+	api.RegisterWalletServer(full, &balanceWallet{balance: 200})
+	go full.Serve(listeners["full"])
+	defer full.Stop()
+	solidity := grpc.NewServer()
+	api.RegisterWalletSolidityServer(solidity, &balanceSolidity{balance: 100})
+	go solidity.Serve(listeners["solidity"])
+	defer solidity.Stop()
+
+	c := client.New("passthrough:///full")
+	c.SetSolidityAddress("passthrough:///solidity")
+	require.Nil(t, c.Start(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return listeners[addr].DialContext(ctx)
+		}),
+	))
+	defer c.Stop()
+
+	acc, err := c.GetAccount(context.Background(), accountAddress)
+	require.Nil(t, err)
+	require.Equal(t, int64(200), acc.Balance)
+
+	acc, err = c.Confirmed().GetAccount(context.Background(), accountAddress)
+	require.Nil(t, err)
+	require.Equal(t, int64(100), acc.Balance)
+}
+
+func TestConfirmedWithoutSolidity(t *testing.T) {
+	c := client.New("passthrough:///unused")
+	require.Nil(t, c.Start(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	defer c.Stop()
+
+	_, err := c.Confirmed().GetAccount(context.Background(), accountAddress)
+	require.ErrorIs(t, err, client.ErrNoSolidityNode)
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+)
+
+const (
+	defaultSubscribePollInterval  = 3 * time.Second
+	defaultSubscribeBatchSize     = 100
+	defaultSubscribeMaxReorgDepth = 64
+)
+
+// ErrReorgTooDeep is returned by a subscription when a fork switch reverts
+// every block it tracks, so the common ancestor is unknown
+var ErrReorgTooDeep = errors.New("subscribe: reorg deeper than tracked blocks")
+
+// BlockEventType tells whether a block joined or left the chain
+type BlockEventType int
+
+const (
+	// BlockApplied block extends the chain
+	BlockApplied BlockEventType = iota
+	// BlockReverted block was orphaned by a fork switch
+	BlockReverted
+)
+
+// String implements fmt.Stringer
+func (t BlockEventType) String() string {
+	if t == BlockReverted {
+		return "reverted"
+	}
+	return "applied"
+}
+
+// BlockRef identifies a block
+type BlockRef struct {
+	Number int64  `json:"number"`
+	ID     []byte `json:"id"`
+}
+
+// BlockEvent a block applied to or reverted from the followed chain
+type BlockEvent struct {
+	Type  BlockEventType
+	Ref   BlockRef
+	Block *api.BlockExtention
+	// Checkpoint to resume from once the event is handled
+	Checkpoint BlockRef
+}
+
+// Subscription follows the chain, see Client.Subscribe
+type Subscription struct {
+	// PollInterval between head checks once caught up
+	PollInterval time.Duration
+	// BatchSize blocks fetched per GetBlockByLimitNext2 call while behind,
+	// 100 at most
+	BatchSize int64
+	// MaxReorgDepth applied blocks kept to detect fork switches
+	MaxReorgDepth int
+	// Solidified only emit blocks the solidity node reports as irreversible,
+	// which never revert. Requires a solidity node.
+	Solidified bool
+	// Checkpoint resume after this block instead of fromBlock. A fork
+	// reverting the checkpoint block ends with ErrReorgTooDeep.
+	Checkpoint *BlockRef
+
+	client *Client
+	events chan BlockEvent
+	err    error
+}
+
+// trackedBlock applied block kept to detect fork switches
+type trackedBlock struct {
+	ref   BlockRef
+	block *api.BlockExtention
+}
+
+// Subscribe follow the chain from block fromBlock, or from the current head
+// when negative. Blocks are emitted in order, gaps backfilled in batches.
+// When a block does not extend the last applied one, the orphaned blocks
+// are emitted as BlockReverted, newest first, before the new branch.
+// The events channel closes when ctx is done or on error, see Err.
+func (g *Client) Subscribe(ctx context.Context, fromBlock int64, options ...func(*Subscription)) (*Subscription, error) {
+	s := &Subscription{
+		PollInterval:  defaultSubscribePollInterval,
+		BatchSize:     defaultSubscribeBatchSize,
+		MaxReorgDepth: defaultSubscribeMaxReorgDepth,
+		client:        g,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.Solidified && g.Solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	if s.BatchSize <= 0 || s.BatchSize > maxBlockLimit {
+		return nil, fmt.Errorf("subscribe: invalid batch size %d", s.BatchSize)
+	}
+	if s.MaxReorgDepth <= 0 {
+		return nil, fmt.Errorf("subscribe: invalid max reorg depth %d", s.MaxReorgDepth)
+	}
+	s.events = make(chan BlockEvent, s.BatchSize)
+	go func() {
+		defer close(s.events)
+		s.err = s.follow(ctx, fromBlock)
+		if ctx.Err() != nil {
+			// calls fail with wrapped status errors once ctx is done
+			s.err = ctx.Err()
+		}
+	}()
+	return s, nil
+}
+
+// Events channel of block events, closed when the subscription ends
+func (s *Subscription) Events() <-chan BlockEvent {
+	return s.events
+}
+
+// Err reason the subscription ended, valid once Events is closed
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+func (s *Subscription) follow(ctx context.Context, next int64) error {
+	var tail []trackedBlock
+	if s.Checkpoint != nil {
+		tail = append(tail, trackedBlock{ref: *s.Checkpoint})
+		next = s.Checkpoint.Number + 1
+	}
+	for {
+		head, err := s.head(ctx)
+		if err != nil {
+			return err
+		}
+		if next < 0 {
+			next = head
+		}
+		if next > head {
+			if err := sleep(ctx, s.PollInterval); err != nil {
+				return err
+			}
+			continue
+		}
+		end := next + s.BatchSize
+		if end > head+1 {
+			end = head + 1
+		}
+		list, err := s.client.GetBlockByLimitNext(ctx, next, end)
+		if err != nil {
+			return fmt.Errorf("subscribe: blocks %d-%d: %v", next, end, err)
+		}
+		if len(list.GetBlock()) == 0 {
+			// node behind the head it reported
+			if err := sleep(ctx, s.PollInterval); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, block := range list.GetBlock() {
+			raw := block.GetBlockHeader().GetRawData()
+			if raw.GetNumber() != next {
+				return fmt.Errorf("subscribe: expected block %d, got %d", next, raw.GetNumber())
+			}
+			if n := len(tail); n > 0 && !bytes.Equal(raw.GetParentHash(), tail[n-1].ref.ID) {
+				orphan := tail[n-1]
+				tail = tail[:n-1]
+				if len(tail) == 0 {
+					return fmt.Errorf("%w at block %d", ErrReorgTooDeep, orphan.ref.Number)
+				}
+				err := s.emit(ctx, BlockEvent{
+					Type:       BlockReverted,
+					Ref:        orphan.ref,
+					Block:      orphan.block,
+					Checkpoint: tail[len(tail)-1].ref,
+				})
+				if err != nil {
+					return err
+				}
+				// refetch the new branch from the orphaned height
+				next = orphan.ref.Number
+				break
+			}
+
+			ref := BlockRef{Number: raw.GetNumber(), ID: block.GetBlockid()}
+			tail = append(tail, trackedBlock{ref: ref, block: block})
+			if len(tail) > s.MaxReorgDepth {
+				tail = tail[len(tail)-s.MaxReorgDepth:]
+			}
+			if err := s.emit(ctx, BlockEvent{Type: BlockApplied, Ref: ref, Block: block, Checkpoint: ref}); err != nil {
+				return err
+			}
+			next++
+		}
+	}
+}
+
+// head latest block number to follow
+func (s *Subscription) head(ctx context.Context) (int64, error) {
+	c := s.client
+	if s.Solidified {
+		c = c.Confirmed()
+	}
+	block, err := c.GetNowBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("subscribe: %v", err)
+	}
+	return block.GetBlockHeader().GetRawData().GetNumber(), nil
+}
+
+func (s *Subscription) emit(ctx context.Context, event BlockEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sleep wait d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
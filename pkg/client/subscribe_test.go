@@ -0,0 +1,165 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/stretchr/testify/require"
+)
+
+func fastPoll(s *client.Subscription) {
+	s.PollInterval = 10 * time.Millisecond
+	s.BatchSize = 2
+}
+
+func nextEvent(t *testing.T, sub *client.Subscription) client.BlockEvent {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		require.True(t, ok, "subscription ended: %v", sub.Err())
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block event")
+	}
+	return client.BlockEvent{}
+}
+
+func requireApplied(t *testing.T, sub *client.Subscription, from, to int64) []client.BlockEvent {
+	t.Helper()
+	var events []client.BlockEvent
+	for num := from; num <= to; num++ {
+		event := nextEvent(t, sub)
+		require.Equal(t, client.BlockApplied, event.Type)
+		require.Equal(t, num, event.Ref.Number)
+		require.Equal(t, event.Ref, event.Checkpoint)
+		events = append(events, event)
+	}
+	return events
+}
+
+func newSubscriptionNode(t *testing.T, blocks int) (*clienttest.Node, *client.Client) {
+	node := clienttest.NewNode(clienttest.WithManualBlocks())
+	t.Cleanup(node.Close)
+	for i := 0; i < blocks; i++ {
+		node.ProduceBlock()
+	}
+	c, err := node.Client()
+	require.Nil(t, err)
+	t.Cleanup(c.Stop)
+	return node, c
+}
+
+func TestSubscribeBackfillAndFollow(t *testing.T) {
+	node, c := newSubscriptionNode(t, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := c.Subscribe(ctx, 2, fastPoll)
+	require.Nil(t, err)
+
+	requireApplied(t, sub, 2, 5)
+	node.ProduceBlock()
+	events := requireApplied(t, sub, 6, 6)
+	require.Equal(t, node.Head(), events[0].Block.BlockHeader.RawData.Number)
+
+	cancel()
+	for range sub.Events() {
+	}
+	require.True(t, errors.Is(sub.Err(), context.Canceled), sub.Err())
+}
+
+func TestSubscribeBatchSize(t *testing.T) {
+	_, c := newSubscriptionNode(t, 5)
+	for _, size := range []int64{0, 101} {
+		_, err := c.Subscribe(context.Background(), 0, func(s *client.Subscription) { s.BatchSize = size })
+		require.ErrorContains(t, err, "invalid batch size")
+	}
+}
+
+func TestSubscribeReorg(t *testing.T) {
+	node, c := newSubscriptionNode(t, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Subscribe(ctx, 1, fastPoll)
+	require.Nil(t, err)
+	applied := requireApplied(t, sub, 1, 4)
+
+	node.Reorg(2)
+	for _, num := range []int64{4, 3} {
+		event := nextEvent(t, sub)
+		require.Equal(t, client.BlockReverted, event.Type)
+		require.Equal(t, applied[num-1].Ref, event.Ref)
+		require.Equal(t, applied[num-2].Ref, event.Checkpoint)
+	}
+	forked := requireApplied(t, sub, 3, 5)
+	require.Equal(t, applied[1].Ref.ID, forked[0].Block.BlockHeader.RawData.ParentHash)
+	require.NotEqual(t, applied[2].Ref.ID, forked[0].Ref.ID)
+}
+
+func TestSubscribeCheckpoint(t *testing.T) {
+	node, c := newSubscriptionNode(t, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Subscribe(ctx, 1, fastPoll)
+	require.Nil(t, err)
+	applied := requireApplied(t, sub, 1, 4)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	checkpoint := applied[1].Checkpoint
+	sub, err = c.Subscribe(ctx, 0, fastPoll, func(s *client.Subscription) {
+		s.Checkpoint = &checkpoint
+	})
+	require.Nil(t, err)
+	requireApplied(t, sub, 3, 4)
+
+	// the fork reaches past the checkpoint
+	checkpoint = applied[3].Checkpoint
+	node.Reorg(2)
+	sub, err = c.Subscribe(ctx, 0, fastPoll, func(s *client.Subscription) {
+		s.Checkpoint = &checkpoint
+	})
+	require.Nil(t, err)
+	for range sub.Events() {
+	}
+	require.True(t, errors.Is(sub.Err(), client.ErrReorgTooDeep), sub.Err())
+}
+
+func TestSubscribeSolidified(t *testing.T) {
+	node := clienttest.NewNode(clienttest.WithManualBlocks())
+	defer node.Close()
+	node.SolidityLag = 2
+	for i := 0; i < 5; i++ {
+		node.ProduceBlock()
+	}
+
+	c := client.New(clienttest.Address)
+	require.Nil(t, c.Start(node.DialOptions()...))
+	_, err := c.Subscribe(context.Background(), 1, func(s *client.Subscription) {
+		s.Solidified = true
+	})
+	require.Equal(t, client.ErrNoSolidityNode, err)
+	c.Stop()
+
+	c = client.New(clienttest.Address)
+	c.SetSolidityAddress(clienttest.Address)
+	require.Nil(t, c.Start(node.DialOptions()...))
+	defer c.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Subscribe(ctx, 1, fastPoll, func(s *client.Subscription) {
+		s.Solidified = true
+	})
+	require.Nil(t, err)
+	requireApplied(t, sub, 1, 3)
+	select {
+	case event := <-sub.Events():
+		t.Fatalf("block %d is not solidified", event.Ref.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+	node.ProduceBlock()
+	requireApplied(t, sub, 4, 4)
+}
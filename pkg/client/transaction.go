@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/elleqt/gotron-sdk/pkg/proto/api"
 	"github.com/elleqt/gotron-sdk/pkg/proto/core"
@@ -15,3 +16,49 @@ func (g *Client) GetTransactionSignWeight(ctx context.Context, tx *core.Transact
 	}
 	return result, nil
 }
+
+// GetTransactionApprovedList queries the addresses that signed a transaction
+func (g *Client) GetTransactionApprovedList(ctx context.Context, tx *core.Transaction) (*api.TransactionApprovedList, error) {
+	result, err := g.Client.GetTransactionApprovedList(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetPermissionID select the account permission signing tx, 0 for owner or
+// the id of an active permission. Txid is updated, sign afterwards.
+func (g *Client) SetPermissionID(tx *api.TransactionExtention, id int32) error {
+	if tx.GetTransaction().GetRawData() == nil {
+		return fmt.Errorf("invalid transaction")
+	}
+	if id < 0 {
+		return fmt.Errorf("invalid permission id %d", id)
+	}
+	for _, c := range tx.Transaction.RawData.Contract {
+		c.PermissionId = id
+	}
+	return g.UpdateHash(tx)
+}
+
+// SetMemo set the transaction memo, raw_data.data, charged the memo fee
+// when not empty. Txid is updated, sign afterwards.
+func (g *Client) SetMemo(tx *api.TransactionExtention, memo string) error {
+	if tx.GetTransaction().GetRawData() == nil {
+		return fmt.Errorf("invalid transaction")
+	}
+	tx.Transaction.RawData.Data = []byte(memo)
+	return g.UpdateHash(tx)
+}
+
+// MemoFee SUN burnt for a memo at the current memo fee, nothing when empty
+func (g *Client) MemoFee(ctx context.Context, memo string) (int64, error) {
+	if len(memo) == 0 {
+		return 0, nil
+	}
+	prices, err := g.GetMemoFee(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return CurrentPrice(prices)
+}
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"errors"
@@ -43,6 +44,8 @@ type behavior struct {
 	DryRun               bool
 	SigningImpl          SignerImpl
 	ConfirmationWaitTime uint32
+	// LedgerOptions select the device and account signing with Ledger
+	LedgerOptions []func(*ledger.Options)
 }
 
 // NewController initializes a Controller, caller can control behavior via options
@@ -63,7 +66,7 @@ func NewController(
 			account: senderAcct,
 		},
 		tx:       tx,
-		Behavior: behavior{false, Software, 0},
+		Behavior: behavior{false, Software, 0, nil},
 	}
 	for _, option := range options {
 		option(ctrlr)
@@ -88,25 +91,31 @@ func (C *Controller) hardwareSignTxForSending() {
 	if C.executionError != nil {
 		return
 	}
-	data, _ := C.GetRawData()
-	signature, err := ledger.SignTx(data)
+	if C.sender.account == nil {
+		C.executionError = ErrBadTransactionParam
+		return
+	}
+	data, err := C.GetRawData()
 	if err != nil {
 		C.executionError = err
 		return
 	}
-
-	/* TODO: validate signature
-	if strings.Compare(signerAddr, address.ToBech32(C.sender.account.Address)) != 0 {
-		C.executionError = ErrBadTransactionParam
-		errorMsg := "signature verification failed : sender address doesn't match with ledger hardware address"
-		C.transactionErrors = append(C.transactionErrors, &Error{
-			ErrMessage:           &errorMsg,
-			TimestampOfRejection: time.Now().Unix(),
-		})
+	signature, err := ledger.SignTx(data, C.Behavior.LedgerOptions...)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	hash := sha256.Sum256(data)
+	signer, err := recoverSigner(hash[:], signature)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	if !bytes.Equal(signer, C.sender.account.Address) {
+		C.executionError = fmt.Errorf("%w: signed by %s, expected %s",
+			ledger.ErrSignerMismatch, signer, C.sender.account.Address)
 		return
 	}
-	*/
-	// add signature
 	C.tx.Signature = append(C.tx.Signature, signature)
 }
 
@@ -138,9 +147,7 @@ func (C *Controller) txConfirmation(ctx context.Context) {
 			// GETTX by ID
 			if txi, err := C.client.GetTransactionInfoByID(ctx, txHash); err == nil {
 				// check receipt
-				if txi.Result != 0 {
-					C.resultError = fmt.Errorf("%s", txi.ResMessage)
-				}
+				C.resultError = C.client.ReceiptError(ctx, txi)
 				// Add receipt
 				C.Receipt = txi
 				return
@@ -159,7 +166,8 @@ func (C *Controller) txConfirmation(ctx context.Context) {
 
 }
 
-// GetResultError return result error
+// GetResultError return the *client.ContractError of a transaction that
+// failed on chain
 func (C *Controller) GetResultError() error {
 	return C.resultError
 }
@@ -168,15 +176,38 @@ func (C *Controller) GetResultError() error {
 // Each step in transaction creation, execution probably includes a mutation
 // Each becomes a no-op if executionError occurred in any previous step
 func (C *Controller) ExecuteTransaction(ctx context.Context) error {
+	C.sign()
+	C.sendSignedTx(ctx)
+	C.txConfirmation(ctx)
+	return C.executionError
+}
+
+// Sign add the sender signature without broadcasting, no network access
+// is needed
+func (C *Controller) Sign() error {
+	C.sign()
+	return C.executionError
+}
+
+// Broadcast send an already signed transaction and wait for confirmation
+func (C *Controller) Broadcast(ctx context.Context) error {
+	C.sendSignedTx(ctx)
+	C.txConfirmation(ctx)
+	return C.executionError
+}
+
+// Transaction being executed, with its signatures once signed
+func (C *Controller) Transaction() *core.Transaction {
+	return C.tx
+}
+
+func (C *Controller) sign() {
 	switch C.Behavior.SigningImpl {
 	case Software:
 		C.signTxForSending()
 	case Ledger:
 		C.hardwareSignTxForSending()
 	}
-	C.sendSignedTx(ctx)
-	C.txConfirmation(ctx)
-	return C.executionError
 }
 
 // GetRawData Byes from Transaction
@@ -189,12 +220,8 @@ func (C *Controller) sendSignedTx(ctx context.Context) {
 		return
 	}
 	result, err := C.client.Broadcast(ctx, C.tx)
+	C.Result = result
 	if err != nil {
 		C.executionError = err
-		return
 	}
-	if result.Code != 0 {
-		C.executionError = fmt.Errorf("bad transaction: %v", string(result.GetMessage()))
-	}
-	C.Result = result
 }
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/elleqt/gotron-sdk/pkg/txdecode"
+	"google.golang.org/protobuf/proto"
+)
+
+// FileVersion of the transaction file format written by File.Write
+const FileVersion = 1
+
+// File portable transaction moved between the online machine building and
+// broadcasting it and the offline one signing it. Raw data is authoritative,
+// Summary only helps reviewing it before signing.
+type File struct {
+	Version int `json:"version"`
+	// TxID hex sha256 of the raw data
+	TxID string `json:"txID"`
+	// RawData hex protobuf encoded raw data
+	RawData      string `json:"rawData"`
+	PermissionID int32  `json:"permissionId"`
+	// Signatures hex, in signing order
+	Signatures []string              `json:"signatures,omitempty"`
+	Summary    *txdecode.Transaction `json:"summary,omitempty"`
+}
+
+// NewFile wrap tx, signed or not, with its decoded summary which may be nil
+func NewFile(tx *core.Transaction, summary *txdecode.Transaction) (*File, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+	f := &File{
+		Version: FileVersion,
+		TxID:    hex.EncodeToString(hash[:]),
+		RawData: hex.EncodeToString(rawData),
+		Summary: summary,
+	}
+	if contracts := tx.GetRawData().GetContract(); len(contracts) > 0 {
+		f.PermissionID = contracts[0].GetPermissionId()
+	}
+	for _, sig := range tx.GetSignature() {
+		f.Signatures = append(f.Signatures, hex.EncodeToString(sig))
+	}
+	return f, nil
+}
+
+// ReadFile load a transaction file
+func ReadFile(path string) (*File, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &File{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("invalid transaction file %s: %v", path, err)
+	}
+	if f.Version != FileVersion {
+		return nil, fmt.Errorf("unsupported transaction file version %d", f.Version)
+	}
+	return f, nil
+}
+
+// Write save the file, readable by the owner only
+func (f *File) Write(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Transaction decode the raw data and signatures, checking the raw data
+// still hashes to TxID
+func (f *File) Transaction() (*core.Transaction, error) {
+	rawData, err := hex.DecodeString(f.RawData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw data: %v", err)
+	}
+	txID, err := hex.DecodeString(f.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txID: %v", err)
+	}
+	if hash := sha256.Sum256(rawData); !bytes.Equal(hash[:], txID) {
+		return nil, fmt.Errorf("raw data does not match txID %s", f.TxID)
+	}
+	tx := &core.Transaction{RawData: &core.TransactionRaw{}}
+	if err := proto.Unmarshal(rawData, tx.RawData); err != nil {
+		return nil, fmt.Errorf("invalid raw data: %v", err)
+	}
+	// signers hash the re-encoded raw data, it has to be the same bytes
+	if encoded, err := proto.Marshal(tx.RawData); err != nil || !bytes.Equal(encoded, rawData) {
+		return nil, fmt.Errorf("raw data is not canonically encoded")
+	}
+	for _, s := range f.Signatures {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %v", err)
+		}
+		tx.Signature = append(tx.Signature, sig)
+	}
+	return tx, nil
+}
+
+// Signers addresses recovered from the signatures, in signing order
+func (f *File) Signers() ([]address.Address, error) {
+	txID, err := hex.DecodeString(f.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txID: %v", err)
+	}
+	signers := make([]address.Address, 0, len(f.Signatures))
+	for _, s := range f.Signatures {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %v", err)
+		}
+		signer, err := recoverSigner(txID, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+// Merge add the signatures of other, the same transaction signed
+// separately, skipping signers already present
+func (f *File) Merge(other *File) error {
+	if other.TxID != f.TxID || other.RawData != f.RawData {
+		return fmt.Errorf("cannot merge transaction %s into %s", other.TxID, f.TxID)
+	}
+	signers, err := f.Signers()
+	if err != nil {
+		return err
+	}
+	theirs, err := other.Signers()
+	if err != nil {
+		return err
+	}
+	signed := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		signed[s.String()] = true
+	}
+	for i, s := range theirs {
+		if !signed[s.String()] {
+			signed[s.String()] = true
+			f.Signatures = append(f.Signatures, other.Signatures[i])
+		}
+	}
+	return nil
+}
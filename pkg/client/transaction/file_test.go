@@ -0,0 +1,178 @@
+package transaction_test
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/client/transaction"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/elleqt/gotron-sdk/pkg/txdecode"
+	"github.com/stretchr/testify/require"
+)
+
+const recipient = "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"
+
+func confirm(c *transaction.Controller) {
+	c.Behavior.ConfirmationWaitTime = 5
+}
+
+func TestFileSignBroadcast(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+
+	ctx := context.Background()
+	tx, err := c.Transfer(ctx, owner, recipient, 1_500_000)
+	require.Nil(t, err)
+	summary, err := txdecode.New(c).Decode(ctx, tx.Transaction)
+	require.Nil(t, err)
+	unsigned, err := transaction.NewFile(tx.Transaction, summary)
+	require.Nil(t, err)
+	require.Equal(t, hex.EncodeToString(tx.Txid), unsigned.TxID)
+	path := filepath.Join(t.TempDir(), "tx.json")
+	require.Nil(t, unsigned.Write(path))
+
+	// offline: no client
+	f, err := transaction.ReadFile(path)
+	require.Nil(t, err)
+	require.Equal(t, owner, f.Summary.Contracts[0].Parameter["owner_address"])
+	offline, err := f.Transaction()
+	require.Nil(t, err)
+	require.Empty(t, offline.Signature)
+	signer := transaction.NewController(nil, ks, &acct, offline)
+	require.Nil(t, signer.Sign())
+	signed, err := transaction.NewFile(signer.Transaction(), f.Summary)
+	require.Nil(t, err)
+	require.Equal(t, f.TxID, signed.TxID)
+	require.Len(t, signed.Signatures, 1)
+	require.Nil(t, signed.Write(path))
+
+	f, err = transaction.ReadFile(path)
+	require.Nil(t, err)
+	online, err := f.Transaction()
+	require.Nil(t, err)
+	ctrlr := transaction.NewController(c, nil, nil, online, confirm)
+	require.Nil(t, ctrlr.Broadcast(ctx))
+	require.Nil(t, ctrlr.GetResultError())
+	require.Equal(t, int64(1), ctrlr.Receipt.BlockNumber)
+	require.Equal(t, int64(8_500_000), node.Balance(owner))
+}
+
+func TestFileTampered(t *testing.T) {
+	f := &transaction.File{
+		Version: transaction.FileVersion,
+		TxID:    "00",
+		RawData: "0a0201",
+	}
+	_, err := f.Transaction()
+	require.NotNil(t, err)
+
+	f.Version = 99
+	path := filepath.Join(t.TempDir(), "tx.json")
+	require.Nil(t, f.Write(path))
+	_, err = transaction.ReadFile(path)
+	require.NotNil(t, err)
+}
+
+func TestFileMultiSignature(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	accts := make([]keystore.Account, 3)
+	for i := range accts {
+		accts[i], err = ks.NewAccount("secret")
+		require.Nil(t, err)
+		require.Nil(t, ks.Unlock(accts[i], "secret"))
+	}
+	owner := accts[0].Address.String()
+	node.Fund(owner, 10_000_000)
+	node.SetPermissions(owner,
+		&core.Permission{PermissionName: "owner", Threshold: 1, Keys: []*core.Key{{Address: accts[0].Address, Weight: 1}}},
+		&core.Permission{PermissionName: "treasury", Threshold: 2, Keys: []*core.Key{
+			{Address: accts[1].Address, Weight: 1},
+			{Address: accts[2].Address, Weight: 1},
+		}},
+	)
+
+	ctx := context.Background()
+	tx, err := c.Transfer(ctx, owner, recipient, 1_500_000)
+	require.Nil(t, err)
+	require.Nil(t, c.SetPermissionID(tx, 2))
+	unsigned, err := transaction.NewFile(tx.Transaction, nil)
+	require.Nil(t, err)
+	require.Equal(t, int32(2), unsigned.PermissionID)
+	require.Equal(t, hex.EncodeToString(tx.Txid), unsigned.TxID)
+
+	// each key holder signs a copy
+	copies := make([]*transaction.File, 2)
+	for i := range copies {
+		f := *unsigned
+		offline, err := f.Transaction()
+		require.Nil(t, err)
+		signer := transaction.NewController(nil, ks, &accts[i+1], offline)
+		require.Nil(t, signer.Sign())
+		copies[i], err = transaction.NewFile(signer.Transaction(), nil)
+		require.Nil(t, err)
+	}
+
+	partial, err := copies[0].Transaction()
+	require.Nil(t, err)
+	weight, err := c.GetTransactionSignWeight(ctx, partial)
+	require.Nil(t, err)
+	require.Equal(t, api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION, weight.Result.Code)
+	require.Equal(t, int64(1), weight.CurrentWeight)
+	require.Equal(t, int64(2), weight.Permission.Threshold)
+	ctrlr := transaction.NewController(c, nil, nil, partial, confirm)
+	require.NotNil(t, ctrlr.Broadcast(ctx))
+
+	merged := copies[0]
+	require.Nil(t, merged.Merge(copies[1]))
+	require.Nil(t, merged.Merge(copies[1]))
+	require.Len(t, merged.Signatures, 2)
+	signers, err := merged.Signers()
+	require.Nil(t, err)
+	require.Equal(t, []address.Address{accts[1].Address, accts[2].Address}, signers)
+	require.NotNil(t, merged.Merge(unsignedOther(t, c, owner)))
+
+	full, err := merged.Transaction()
+	require.Nil(t, err)
+	weight, err = c.GetTransactionSignWeight(ctx, full)
+	require.Nil(t, err)
+	require.Equal(t, api.TransactionSignWeight_Result_ENOUGH_PERMISSION, weight.Result.Code)
+	approved, err := c.GetTransactionApprovedList(ctx, full)
+	require.Nil(t, err)
+	require.Len(t, approved.ApprovedList, 2)
+
+	ctrlr = transaction.NewController(c, nil, nil, full, confirm)
+	require.Nil(t, ctrlr.Broadcast(ctx))
+	require.Equal(t, int64(8_500_000), node.Balance(owner))
+}
+
+// unsignedOther file of another transaction
+func unsignedOther(t *testing.T, c *client.Client, owner string) *transaction.File {
+	tx, err := c.Transfer(context.Background(), owner, recipient, 1)
+	require.Nil(t, err)
+	f, err := transaction.NewFile(tx.Transaction, nil)
+	require.Nil(t, err)
+	return f
+}
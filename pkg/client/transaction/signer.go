@@ -1,8 +1,24 @@
 package transaction
 
+import (
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
 type SignerImpl int
 
 const (
 	Software SignerImpl = iota
 	Ledger
 )
+
+// recoverSigner address that produced sig over hash
+func recoverSigner(hash, sig []byte) (address.Address, error) {
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	return address.PubkeyToAddress(*pub), nil
+}
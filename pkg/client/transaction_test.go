@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/elleqt/gotron-sdk/pkg/txdecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo(t *testing.T) {
+	node := clienttest.NewNode()
+	defer node.Close()
+	c, err := node.Client()
+	require.Nil(t, err)
+	defer c.Stop()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	acct, err := ks.NewAccount("secret")
+	require.Nil(t, err)
+	require.Nil(t, ks.Unlock(acct, "secret"))
+	owner := acct.Address.String()
+	node.Fund(owner, 10_000_000)
+
+	ctx := context.Background()
+	tx, err := c.Transfer(ctx, owner, accountAddress, 1_000_000)
+	require.Nil(t, err)
+	txid := tx.Txid
+	require.Nil(t, c.SetMemo(tx, "deposit 42"))
+	require.NotEqual(t, txid, tx.Txid)
+	decoded, err := txdecode.New(nil).Decode(ctx, tx.Transaction)
+	require.Nil(t, err)
+	require.Equal(t, "deposit 42", decoded.Memo)
+
+	fee, err := c.MemoFee(ctx, "deposit 42")
+	require.Nil(t, err)
+	require.Equal(t, int64(1_000_000), fee)
+	fee, err = c.MemoFee(ctx, "")
+	require.Nil(t, err)
+	require.Zero(t, fee)
+
+	_, err = ks.SignTx(acct, tx.Transaction)
+	require.Nil(t, err)
+	_, err = c.Broadcast(ctx, tx.Transaction)
+	require.Nil(t, err)
+	var info *core.TransactionInfo
+	require.Eventually(t, func() bool {
+		info, err = c.GetTransactionInfoByID(ctx, decoded.ID)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(1_000_000), info.GetFee())
+	require.Equal(t, int64(8_000_000), node.Balance(owner))
+}
+
+func TestCurrentPrice(t *testing.T) {
+	price, err := client.CurrentPrice(&api.PricesResponseMessage{Prices: "0:100,1575871200000:10,1606537680000:40,1614238080000:140"})
+	require.Nil(t, err)
+	require.Equal(t, int64(140), price)
+
+	for _, invalid := range []string{"", "0", "0:x"} {
+		_, err = client.CurrentPrice(&api.PricesResponseMessage{Prices: invalid})
+		require.NotNil(t, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"unicode/utf8"
 
+	"github.com/elleqt/gotron-sdk/pkg/abi"
 	"github.com/elleqt/gotron-sdk/pkg/address"
 	"github.com/elleqt/gotron-sdk/pkg/common"
 	"github.com/elleqt/gotron-sdk/pkg/proto/api"
@@ -56,8 +57,19 @@ func (g *Client) TRC20Call(ctx context.Context, from, contractAddress, data stri
 	if err != nil {
 		return nil, err
 	}
-	if result.Result.Code > 0 {
-		return result, fmt.Errorf(string(result.Result.Message))
+	if err := resultError(result.GetResult()); err != nil {
+		return result, err
+	}
+	if constant && callFailed(result) {
+		var data []byte
+		if results := result.GetConstantResult(); len(results) > 0 {
+			data = results[0]
+		}
+		return result, &ContractError{
+			Result:  core.Transaction_Result_REVERT,
+			Message: string(result.GetResult().GetMessage()),
+			Revert:  abi.DecodeRevert(data, g.contractABI(ctx, contractDesc.Bytes())),
+		}
 	}
 	return result, nil
 
@@ -2,23 +2,47 @@ package client_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
+	"github.com/elleqt/gotron-sdk/pkg/abi"
 	"github.com/elleqt/gotron-sdk/pkg/client"
+	"github.com/elleqt/gotron-sdk/pkg/client/clienttest"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/grpc"
 )
 
 func TestTRC20_Balance(t *testing.T) {
-	trc20Contract := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" // USDT
+	trc20Contract := usdt
 	address := "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
 
-	conn := client.New("grpc.trongrid.io:50051")
-	err := conn.Start(grpc.WithInsecure())
-	require.Nil(t, err)
+	node, conn := newTestNode(t)
+	node.HandleCall(trc20Contract, "balanceOf(address)", func(*core.TriggerSmartContract) ([]byte, error) {
+		return common.LeftPadBytes(big.NewInt(1_250_000).Bytes(), 32), nil
+	})
 
 	balance, err := conn.TRC20ContractBalance(context.Background(), address, trc20Contract)
 	assert.Nil(t, err)
-	assert.Greater(t, balance.Int64(), int64(0))
+	assert.Equal(t, int64(1_250_000), balance.Int64())
+}
+
+func TestTRC20_Revert(t *testing.T) {
+	node, conn := newTestNode(t)
+	node.HandleCall(usdt, "decimals()", func(*core.TriggerSmartContract) ([]byte, error) {
+		data, err := abi.Pack("Error(string)", []abi.Param{{"string": "paused"}})
+		if err != nil {
+			return nil, err
+		}
+		return nil, &clienttest.Revert{Data: data}
+	})
+
+	_, err := conn.TRC20GetDecimals(context.Background(), usdt)
+	require.ErrorIs(t, err, client.ErrRevert)
+	var contractErr *client.ContractError
+	require.ErrorAs(t, err, &contractErr)
+	require.Equal(t, "REVERT opcode executed", contractErr.Message)
+	require.NotNil(t, contractErr.Revert)
+	require.Equal(t, "paused", contractErr.Revert.Reason)
 }
@@ -0,0 +1,32 @@
+package jsonrpc
+
+import (
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ToEthAddress drop the TRON prefix byte from a 21 byte address
+func ToEthAddress(addr address.Address) (ethcommon.Address, error) {
+	if len(addr) != address.AddressLength || addr[0] != address.TronBytePrefix {
+		return ethcommon.Address{}, fmt.Errorf("invalid tron address %x", []byte(addr))
+	}
+	return ethcommon.BytesToAddress(addr[1:]), nil
+}
+
+// FromEthAddress add the TRON prefix byte to a 20 byte address
+func FromEthAddress(addr ethcommon.Address) address.Address {
+	tronAddr := make(address.Address, 0, address.AddressLength)
+	tronAddr = append(tronAddr, address.TronBytePrefix)
+	return append(tronAddr, addr.Bytes()...)
+}
+
+// Base58ToEthAddress convert base58 TRON address to 0x form
+func Base58ToEthAddress(s string) (ethcommon.Address, error) {
+	addr, err := address.Base58ToAddress(s)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+	return ToEthAddress(addr)
+}
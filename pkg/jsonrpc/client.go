@@ -0,0 +1,121 @@
+// Package jsonrpc implements a client for the eth compatible JSON-RPC
+// endpoint (/jsonrpc) exposed by java-tron nodes.
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// APIKeyHeader header used by TronGrid to identify API keys
+	APIKeyHeader   = "TRON-PRO-API-KEY"
+	defaultTimeout = 30 * time.Second
+)
+
+// Error returned by the node for a failed call
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("jsonrpc error %d: %s (%s)", e.Code, e.Message, string(e.Data))
+	}
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Client JSON-RPC controller structure
+type Client struct {
+	URL    string
+	HTTP   *http.Client
+	apiKey string
+	nextID uint64
+}
+
+// New create JSON-RPC client for the endpoint URL,
+// e.g. https://api.trongrid.io/jsonrpc
+func New(url string) *Client {
+	return &Client{
+		URL:  url,
+		HTTP: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// SetAPIKey sets the TronGrid API key sent on every call
+func (c *Client) SetAPIKey(apiKey string) {
+	c.apiKey = apiKey
+}
+
+// CallContext perform a raw JSON-RPC call and decode the result into result
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(&request{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.apiKey) > 0 {
+		req.Header.Set(APIKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %v", method, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %v", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: http %d: %s", method, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var res response
+	if err := json.Unmarshal(data, &res); err != nil {
+		return fmt.Errorf("%s: %v", method, err)
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if result == nil || len(res.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
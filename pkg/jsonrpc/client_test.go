@@ -0,0 +1,150 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/jsonrpc"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usdtBase58 = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	usdtEth    = "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	txID       = "9f6b5b4bd2f5a1e7c6ad9a0c7a6b33b3b0e1d0f0f6f2a5c6e5cc0c8a0e1a0b0c"
+)
+
+func startNode(t *testing.T, handler func(method string, params []json.RawMessage) interface{}) *jsonrpc.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "key", r.Header.Get(jsonrpc.APIKeyHeader))
+		result := handler(req.Method, req.Params)
+		if err, ok := result.(*jsonrpc.Error); ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": err})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	c := jsonrpc.New(srv.URL + "/jsonrpc")
+	c.SetAPIKey("key")
+	return c
+}
+
+func TestAddressConversion(t *testing.T) {
+	eth, err := jsonrpc.Base58ToEthAddress(usdtBase58)
+	require.Nil(t, err)
+	require.Equal(t, ethcommon.HexToAddress(usdtEth), eth)
+	require.Equal(t, usdtBase58, jsonrpc.FromEthAddress(eth).String())
+
+	_, err = jsonrpc.ToEthAddress(address.Address{0x01, 0x02})
+	require.NotNil(t, err)
+}
+
+func TestBlockNumber(t *testing.T) {
+	c := startNode(t, func(method string, _ []json.RawMessage) interface{} {
+		require.Equal(t, "eth_blockNumber", method)
+		return "0x3d0900"
+	})
+	n, err := c.BlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint64(4000000), n)
+}
+
+func TestCall(t *testing.T) {
+	c := startNode(t, func(method string, params []json.RawMessage) interface{} {
+		require.Equal(t, "eth_call", method)
+		require.JSONEq(t, `{"to":"`+usdtEth+`","data":"0x313ce567"}`, string(params[0]))
+		require.JSONEq(t, `"latest"`, string(params[1]))
+		return "0x0000000000000000000000000000000000000000000000000000000000000006"
+	})
+	to, _ := address.Base58ToAddress(usdtBase58)
+	out, err := c.Call(context.Background(), jsonrpc.CallMsg{To: to, Data: []byte{0x31, 0x3c, 0xe5, 0x67}}, "")
+	require.Nil(t, err)
+	require.Equal(t, int64(6), new(big.Int).SetBytes(out).Int64())
+}
+
+func TestGetLogs(t *testing.T) {
+	transfer := ethcommon.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	c := startNode(t, func(method string, params []json.RawMessage) interface{} {
+		require.Equal(t, "eth_getLogs", method)
+		require.JSONEq(t, `{
+			"fromBlock":"0x64","toBlock":"0xc8",
+			"address":["`+usdtEth+`"],
+			"topics":["`+transfer.Hex()+`"]
+		}`, string(params[0]))
+		return []map[string]interface{}{{
+			"address":          usdtEth,
+			"topics":           []string{transfer.Hex()},
+			"data":             "0x01",
+			"blockNumber":      "0x65",
+			"blockHash":        "0x" + txID,
+			"transactionHash":  "0x" + txID,
+			"transactionIndex": "0x0",
+			"logIndex":         "0x2",
+			"removed":          false,
+		}}
+	})
+	contract, _ := address.Base58ToAddress(usdtBase58)
+	logs, err := c.GetLogs(context.Background(), jsonrpc.FilterQuery{
+		FromBlock: big.NewInt(100),
+		ToBlock:   big.NewInt(200),
+		Addresses: []address.Address{contract},
+		Topics:    [][]ethcommon.Hash{{transfer}},
+	})
+	require.Nil(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, usdtBase58, logs[0].Address.String())
+	require.Equal(t, uint64(101), logs[0].BlockNumber)
+	require.Equal(t, uint64(2), logs[0].Index)
+	require.Equal(t, txID, logs[0].TransactionHash)
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	c := startNode(t, func(method string, params []json.RawMessage) interface{} {
+		require.Equal(t, "eth_getTransactionReceipt", method)
+		if string(params[0]) != `"0x`+txID+`"` {
+			return nil
+		}
+		return map[string]interface{}{
+			"transactionHash": "0x" + txID,
+			"blockNumber":     "0x65",
+			"to":              usdtEth,
+			"contractAddress": nil,
+			"gasUsed":         "0x3421",
+			"status":          "0x1",
+			"logs":            []interface{}{},
+		}
+	})
+	receipt, err := c.GetTransactionReceipt(context.Background(), txID)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+	require.Equal(t, usdtBase58, receipt.To.String())
+	require.Nil(t, receipt.ContractAddress)
+	require.Equal(t, uint64(0x3421), receipt.GasUsed)
+
+	receipt, err = c.GetTransactionReceipt(context.Background(), "00")
+	require.Nil(t, err)
+	require.Nil(t, receipt)
+}
+
+func TestRPCError(t *testing.T) {
+	c := startNode(t, func(string, []json.RawMessage) interface{} {
+		return &jsonrpc.Error{Code: -32005, Message: "exceed max block range"}
+	})
+	_, err := c.GetLogs(context.Background(), jsonrpc.FilterQuery{})
+	var rpcErr *jsonrpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32005, rpcErr.Code)
+}
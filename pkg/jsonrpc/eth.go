@@ -0,0 +1,259 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockLatest tag for the most recent block, the only tag eth_call accepts on TRON
+const BlockLatest = "latest"
+
+// CallMsg parameters of an eth_call
+type CallMsg struct {
+	From  address.Address
+	To    address.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// FilterQuery parameters of an eth_getLogs range filter
+type FilterQuery struct {
+	// BlockHash restricts logs to a single block, exclusive with FromBlock/ToBlock
+	BlockHash *ethcommon.Hash
+	// FromBlock first block, nil for latest
+	FromBlock *big.Int
+	// ToBlock last block, nil for latest
+	ToBlock *big.Int
+	// Addresses emitting contracts, empty for any
+	Addresses []address.Address
+	// Topics by position, empty position matches any topic
+	Topics [][]ethcommon.Hash
+}
+
+// Log event emitted by a contract
+type Log struct {
+	Address          address.Address
+	Topics           []ethcommon.Hash
+	Data             []byte
+	BlockNumber      uint64
+	BlockHash        ethcommon.Hash
+	TransactionHash  string
+	TransactionIndex uint64
+	Index            uint64
+	Removed          bool
+}
+
+type rpcLog struct {
+	Address          ethcommon.Address `json:"address"`
+	Topics           []ethcommon.Hash  `json:"topics"`
+	Data             hexutil.Bytes     `json:"data"`
+	BlockNumber      hexutil.Uint64    `json:"blockNumber"`
+	BlockHash        ethcommon.Hash    `json:"blockHash"`
+	TransactionHash  ethcommon.Hash    `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64    `json:"transactionIndex"`
+	Index            hexutil.Uint64    `json:"logIndex"`
+	Removed          bool              `json:"removed"`
+}
+
+// UnmarshalJSON decode log converting addresses to TRON form
+func (l *Log) UnmarshalJSON(data []byte) error {
+	var raw rpcLog
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*l = Log{
+		Address:          FromEthAddress(raw.Address),
+		Topics:           raw.Topics,
+		Data:             raw.Data,
+		BlockNumber:      uint64(raw.BlockNumber),
+		BlockHash:        raw.BlockHash,
+		TransactionHash:  txHash(raw.TransactionHash),
+		TransactionIndex: uint64(raw.TransactionIndex),
+		Index:            uint64(raw.Index),
+		Removed:          raw.Removed,
+	}
+	return nil
+}
+
+// Receipt of an executed transaction
+type Receipt struct {
+	TransactionHash   string
+	TransactionIndex  uint64
+	BlockHash         ethcommon.Hash
+	BlockNumber       uint64
+	From              address.Address
+	To                address.Address
+	ContractAddress   address.Address
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	EffectiveGasPrice uint64
+	Status            uint64
+	Logs              []Log
+}
+
+type rpcReceipt struct {
+	TransactionHash   ethcommon.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64     `json:"transactionIndex"`
+	BlockHash         ethcommon.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64     `json:"blockNumber"`
+	From              *ethcommon.Address `json:"from"`
+	To                *ethcommon.Address `json:"to"`
+	ContractAddress   *ethcommon.Address `json:"contractAddress"`
+	GasUsed           hexutil.Uint64     `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64     `json:"cumulativeGasUsed"`
+	EffectiveGasPrice hexutil.Uint64     `json:"effectiveGasPrice"`
+	Status            hexutil.Uint64     `json:"status"`
+	Logs              []Log              `json:"logs"`
+}
+
+// UnmarshalJSON decode receipt converting addresses to TRON form
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	var raw rpcReceipt
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	optional := func(a *ethcommon.Address) address.Address {
+		if a == nil {
+			return nil
+		}
+		return FromEthAddress(*a)
+	}
+	*r = Receipt{
+		TransactionHash:   txHash(raw.TransactionHash),
+		TransactionIndex:  uint64(raw.TransactionIndex),
+		BlockHash:         raw.BlockHash,
+		BlockNumber:       uint64(raw.BlockNumber),
+		From:              optional(raw.From),
+		To:                optional(raw.To),
+		ContractAddress:   optional(raw.ContractAddress),
+		GasUsed:           uint64(raw.GasUsed),
+		CumulativeGasUsed: uint64(raw.CumulativeGasUsed),
+		EffectiveGasPrice: uint64(raw.EffectiveGasPrice),
+		Status:            uint64(raw.Status),
+		Logs:              raw.Logs,
+	}
+	return nil
+}
+
+// txHash TRON transaction id form, hex without 0x
+func txHash(h ethcommon.Hash) string {
+	return strings.TrimPrefix(h.Hex(), "0x")
+}
+
+// toBlockArg encode block number, nil means latest
+func toBlockArg(number *big.Int) string {
+	if number == nil {
+		return BlockLatest
+	}
+	return hexutil.EncodeBig(number)
+}
+
+// BlockNumber return the latest block number
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var result hexutil.Uint64
+	if err := c.CallContext(ctx, &result, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// ChainID return the network chain id
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.CallContext(ctx, &result, "eth_chainId"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// Call execute a constant call and return its output
+func (c *Client) Call(ctx context.Context, msg CallMsg, block string) ([]byte, error) {
+	arg := map[string]interface{}{
+		"data": hexutil.Bytes(msg.Data),
+	}
+	if len(msg.From) > 0 {
+		from, err := ToEthAddress(msg.From)
+		if err != nil {
+			return nil, err
+		}
+		arg["from"] = from
+	}
+	to, err := ToEthAddress(msg.To)
+	if err != nil {
+		return nil, err
+	}
+	arg["to"] = to
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if len(block) == 0 {
+		block = BlockLatest
+	}
+
+	var result hexutil.Bytes
+	if err := c.CallContext(ctx, &result, "eth_call", arg, block); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLogs return logs matching the filter query
+func (c *Client) GetLogs(ctx context.Context, q FilterQuery) ([]Log, error) {
+	arg := map[string]interface{}{}
+	if q.BlockHash != nil {
+		arg["blockHash"] = *q.BlockHash
+	} else {
+		arg["fromBlock"] = toBlockArg(q.FromBlock)
+		arg["toBlock"] = toBlockArg(q.ToBlock)
+	}
+	if len(q.Addresses) > 0 {
+		addresses := make([]ethcommon.Address, 0, len(q.Addresses))
+		for _, a := range q.Addresses {
+			ethAddr, err := ToEthAddress(a)
+			if err != nil {
+				return nil, err
+			}
+			addresses = append(addresses, ethAddr)
+		}
+		arg["address"] = addresses
+	}
+	if len(q.Topics) > 0 {
+		topics := make([]interface{}, len(q.Topics))
+		for i, position := range q.Topics {
+			switch len(position) {
+			case 0:
+				topics[i] = nil
+			case 1:
+				topics[i] = position[0]
+			default:
+				topics[i] = position
+			}
+		}
+		arg["topics"] = topics
+	}
+
+	var result []Log
+	if err := c.CallContext(ctx, &result, "eth_getLogs", arg); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTransactionReceipt return receipt by TRON transaction id (with or
+// without 0x prefix), nil if the transaction is unknown
+func (c *Client) GetTransactionReceipt(ctx context.Context, id string) (*Receipt, error) {
+	if !strings.HasPrefix(id, "0x") {
+		id = "0x" + id
+	}
+	var result *Receipt
+	if err := c.CallContext(ctx, &result, "eth_getTransactionReceipt", id); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zondax/hid"
+)
+
+const (
+	ledgerVendorID = 0x2c97
+	// ledgerUsagePage APDU interface on Windows and macOS
+	ledgerUsagePage = 0xffa0
+)
+
+// models Ledger models by the upper byte of the product id, legacy product
+// ids included
+var models = map[uint16]string{
+	0x0001: "Nano S",
+	0x0004: "Nano X",
+	0x0005: "Nano S Plus",
+	0x10:   "Nano S",
+	0x40:   "Nano X",
+	0x50:   "Nano S Plus",
+}
+
+// DeviceInfo connected Ledger device
+type DeviceInfo struct {
+	// Path HID path selecting the device
+	Path   string `json:"path"`
+	Model  string `json:"model"`
+	Serial string `json:"serial,omitempty"`
+}
+
+// Devices connected Nano S, Nano S Plus and Nano X devices
+func Devices() []DeviceInfo {
+	var devices []DeviceInfo
+	for _, info := range enumerate() {
+		devices = append(devices, DeviceInfo{
+			Path:   info.Path,
+			Model:  model(info.ProductID),
+			Serial: info.Serial,
+		})
+	}
+	return devices
+}
+
+func enumerate() []hid.DeviceInfo {
+	var devices []hid.DeviceInfo
+	for _, info := range hid.Enumerate(ledgerVendorID, 0) {
+		if model(info.ProductID) == "" {
+			continue
+		}
+		// one interface of the device exchanges APDUs
+		if info.UsagePage != ledgerUsagePage && info.Interface != 0 {
+			continue
+		}
+		devices = append(devices, info)
+	}
+	return devices
+}
+
+func model(productID uint16) string {
+	if m, ok := models[productID]; ok {
+		return m
+	}
+	return models[productID>>8]
+}
+
+// Open the device at a HID path, the only connected device when path is
+// empty, or the Speculos emulator at a tcp://host:port path
+func Open(path string) (*NanoS, error) {
+	if strings.HasPrefix(path, tcpScheme) {
+		return DialTCP(strings.TrimPrefix(path, tcpScheme))
+	}
+	devices := enumerate()
+	var found *hid.DeviceInfo
+	for i := range devices {
+		if len(path) == 0 || devices[i].Path == path {
+			if found != nil {
+				return nil, fmt.Errorf("ledger: %d devices connected, select one", len(devices))
+			}
+			found = &devices[i]
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+
+	device, err := found.Open()
+	if err != nil {
+		return nil, err
+	}
+	// wrap raw device I/O in HID+APDU protocols
+	return NewNanoS(&apduFramer{
+		hf: &hidFramer{
+			rw: device,
+		},
+	}), nil
+}
+
+// OpenNanoS open the only connected device
+func OpenNanoS() (*NanoS, error) {
+	return Open("")
+}
@@ -0,0 +1,50 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound no Ledger device is connected
+	ErrNotFound = errors.New("ledger: device not found")
+	// ErrLocked device is locked by its PIN
+	ErrLocked = errors.New("ledger: device locked")
+	// ErrWrongApp TRON app is not open on the device
+	ErrWrongApp = errors.New("ledger: TRON app not open")
+	// ErrUserRejected user rejected the request on the device
+	ErrUserRejected = errors.New("ledger: user rejected the request")
+	// ErrInvalidParam app refused the request parameters
+	ErrInvalidParam = errors.New("ledger: invalid request parameters")
+	// ErrSignerMismatch signature does not match the sending account
+	ErrSignerMismatch = errors.New("ledger: signer does not match the sender")
+)
+
+// ErrCode APDU status word of a failed command
+type ErrCode uint16
+
+// statusErrors meaning of status words
+var statusErrors = map[ErrCode]error{
+	0x5515: ErrLocked,
+	0x6982: ErrLocked,
+	0x6511: ErrWrongApp,
+	0x6d00: ErrWrongApp,
+	0x6e00: ErrWrongApp,
+	0x6e01: ErrWrongApp,
+	0x6985: ErrUserRejected,
+	0x6a80: ErrInvalidParam,
+	0x6b01: ErrInvalidParam,
+}
+
+// Error implements error
+func (c ErrCode) Error() string {
+	if err, ok := statusErrors[c]; ok {
+		return fmt.Sprintf("%v (0x%04x)", err, uint16(c))
+	}
+	return fmt.Sprintf("ledger: error code 0x%04x", uint16(c))
+}
+
+// Unwrap the error of a known status word, for errors.Is
+func (c ErrCode) Unwrap() error {
+	return statusErrors[c]
+}
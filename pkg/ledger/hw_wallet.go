@@ -1,89 +1,140 @@
 package ledger
 
 import (
+	"crypto/sha256"
 	"fmt"
-	"log"
-	"os"
 	"sync"
 
-	"golang.org/x/crypto/sha3"
-
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 var (
-	nanos *NanoS //singleton
-	once  sync.Once
+	opened = make(map[string]*NanoS) // by device path or emulator address
+	mu     sync.Mutex
 )
 
-func getLedger() *NanoS {
-	once.Do(func() {
-		var err error
-		nanos, err = OpenNanoS()
-		if err != nil {
-			log.Fatalln("Couldn't open device:", err)
-			os.Exit(-1)
-		}
-	})
+// Options device and account the package functions use
+type Options struct {
+	// Device HID path of the device or tcp://host:port of a Speculos
+	// emulator, ProxyAddressEnv or the only connected device by default
+	Device string
+	// Path BIP44 path of the account, DefaultPath by default
+	Path []uint32
+	// Confirm show the address on the device for the user to confirm
+	Confirm bool
+}
+
+// WithDevice use the device at a HID path listed by Devices, or the
+// Speculos emulator at tcp://host:port
+func WithDevice(path string) func(*Options) {
+	return func(o *Options) {
+		o.Device = path
+	}
+}
 
-	return nanos
+// WithPath use the account at a BIP44 path
+func WithPath(path []uint32) func(*Options) {
+	return func(o *Options) {
+		o.Path = path
+	}
+}
+
+// WithConfirm show the address on the device for the user to confirm
+func WithConfirm() func(*Options) {
+	return func(o *Options) {
+		o.Confirm = true
+	}
+}
+
+func newOptions(options []func(*Options)) *Options {
+	o := &Options{Device: proxyDevice(), Path: DefaultPath}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// getLedger open the device on first use
+func getLedger(device string) (*NanoS, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if n, ok := opened[device]; ok {
+		return n, nil
+	}
+	n, err := Open(device)
+	if err != nil {
+		return nil, err
+	}
+	opened[device] = n
+	return n, nil
 }
 
-// GetAddress ProcessAddressCommand list the address associated with Ledger Nano S
-func GetAddress() string {
-	n := getLedger()
-	addr, err := n.GetAddress()
+// GetAddress address of an account of the Ledger, the first one by default
+func GetAddress(options ...func(*Options)) (string, error) {
+	o := newOptions(options)
+	n, err := getLedger(o.Device)
 	if err != nil {
-		log.Fatalln("Couldn't get address:", err)
-		os.Exit(-1)
+		return "", err
 	}
+	return n.Address(o.Path, o.Confirm)
+}
 
-	return addr
+// GetAppConfiguration version and settings of the TRON app
+func GetAppConfiguration(options ...func(*Options)) (*AppConfig, error) {
+	n, err := getLedger(newOptions(options).Device)
+	if err != nil {
+		return nil, err
+	}
+	return n.AppConfiguration()
 }
 
 // ProcessAddressCommand list the address associated with Ledger Nano S
-func ProcessAddressCommand() {
-	n := getLedger()
-	addr, err := n.GetAddress()
+func ProcessAddressCommand(options ...func(*Options)) error {
+	o := newOptions(options)
+	addr, err := GetAddress(options...)
 	if err != nil {
-		log.Fatalln("Couldn't get address:", err)
-		os.Exit(-1)
+		return err
 	}
 
 	fmt.Printf("%-24s\t\t%23s\n", "NAME", "ADDRESS")
-	fmt.Printf("%-48s\t%s\n", "Ledger Nano S", addr)
+	fmt.Printf("%-48s\t%s\n", "Ledger "+FormatPath(o.Path), addr)
+	return nil
 }
 
-// SignTx signs the given transaction with the requested account.
-func SignTx(tx []byte) ([]byte, error) {
-
-	n := getLedger()
-	sig, err := n.SignTxn(tx)
+// SignTx sign the raw data of a transaction with an account of the Ledger
+// and return the 65 bytes signature
+func SignTx(tx []byte, options ...func(*Options)) ([]byte, error) {
+	o := newOptions(options)
+	n, err := getLedger(o.Device)
 	if err != nil {
-		log.Println("Couldn't sign transaction, error:", err)
 		return nil, err
 	}
-
-	var hashBytes [32]byte
-	hw := sha3.NewLegacyKeccak256()
-	hw.Write(tx[:])
-	hw.Sum(hashBytes[:0])
-
-	pubkey, err := crypto.Ecrecover(hashBytes[:], sig[:])
+	sig, err := n.SignTxnAt(o.Path, tx)
 	if err != nil {
-		log.Println("Ecrecover failed :", err)
 		return nil, err
 	}
+	hash := sha256.Sum256(tx)
+	if _, err := crypto.SigToPub(hash[:], sig); err != nil {
+		return nil, fmt.Errorf("ledger: invalid signature: %v", err)
+	}
+	return sig, nil
+}
 
-	if len(pubkey) == 0 || pubkey[0] != 4 {
-		log.Println("invalid public key")
+// SignMessage sign a TIP-191 personal message with an account of the
+// Ledger, the signature verifies against keystore.TextHash(msg)
+func SignMessage(msg []byte, options ...func(*Options)) ([]byte, error) {
+	o := newOptions(options)
+	n, err := getLedger(o.Device)
+	if err != nil {
 		return nil, err
 	}
-
-	//pubBytes := crypto.Keccak256(pubkey[1:65])[12:]
-	//signerAddr, _ := address.PubkeyToAddress(pubBytes)
-
-	// TODO:
-	//return sig, nil
-	return nil, nil
+	sig, err := n.SignMessage(o.Path, msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := crypto.SigToPub(keystore.TextHash(msg), sig); err != nil {
+		return nil, fmt.Errorf("ledger: invalid signature: %v", err)
+	}
+	return sig, nil
 }
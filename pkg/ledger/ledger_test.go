@@ -0,0 +1,189 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApp TRON app answering APDUs with a software key per path
+type fakeApp struct {
+	keys   map[string]*ecdsa.PrivateKey
+	status uint16
+	apdus  []APDU
+	path   []byte
+	data   []byte
+}
+
+func (a *fakeApp) key(path []byte) *ecdsa.PrivateKey {
+	if key, ok := a.keys[string(path)]; ok {
+		return key
+	}
+	key, _ := crypto.GenerateKey()
+	a.keys[string(path)] = key
+	return key
+}
+
+// start read the path of the first chunk of a command
+func (a *fakeApp) start(payload []byte) []byte {
+	n := 1 + 4*int(payload[0])
+	a.path, a.data = payload[:n], nil
+	return payload[n:]
+}
+
+func (a *fakeApp) Exchange(apdu APDU) ([]byte, error) {
+	a.apdus = append(a.apdus, apdu)
+	if a.status != codeSuccess {
+		return []byte{byte(a.status >> 8), byte(a.status)}, nil
+	}
+	var resp []byte
+	switch apdu.INS {
+	case cmdGetAppConfiguration:
+		resp = []byte{0x03, 0, 5, 1}
+	case cmdGetPublicKey:
+		key := a.key(apdu.Payload)
+		pub := crypto.FromECDSAPub(&key.PublicKey)
+		addr := address.PubkeyToAddress(key.PublicKey).String()
+		resp = append(append(append([]byte{byte(len(pub))}, pub...), byte(len(addr))), addr...)
+	case cmdSignTx:
+		payload := apdu.Payload
+		if apdu.P1 == p1Single || apdu.P1 == p1First {
+			payload = a.start(payload)
+		}
+		a.data = append(a.data, payload...)
+		if apdu.P1 == p1Single || apdu.P1 == p1Last {
+			hash := sha256.Sum256(a.data)
+			resp = a.sign(hash[:])
+		}
+	case cmdSignPersonalMessage:
+		payload := apdu.Payload
+		if apdu.P1 == p1First {
+			payload = a.start(payload)
+		}
+		a.data = append(a.data, payload...)
+		if len(a.data) == 4+int(binary.BigEndian.Uint32(a.data)) {
+			resp = a.sign(keystore.TextHash(a.data[4:]))
+		}
+	}
+	return append(resp, 0x90, 0x00), nil
+}
+
+func (a *fakeApp) Close() error {
+	return nil
+}
+
+func (a *fakeApp) sign(hash []byte) []byte {
+	sig, _ := crypto.Sign(hash, a.key(a.path))
+	sig[64] += 27
+	return sig
+}
+
+func (a *fakeApp) address(path []uint32) address.Address {
+	return address.PubkeyToAddress(a.key(encodePath(path)).PublicKey)
+}
+
+func newFakeApp() *fakeApp {
+	return &fakeApp{keys: make(map[string]*ecdsa.PrivateKey), status: codeSuccess}
+}
+
+func useFakeApp(t *testing.T) *fakeApp {
+	t.Setenv(ProxyAddressEnv, "")
+	app := newFakeApp()
+	opened[""] = NewNanoS(app)
+	t.Cleanup(func() { delete(opened, "") })
+	return app
+}
+
+func TestPath(t *testing.T) {
+	path, err := ParsePath("m/44'/195'/1'/0/7")
+	require.Nil(t, err)
+	require.Equal(t, AccountPath(1, 7), path)
+	require.Equal(t, "m/44'/195'/1'/0/7", FormatPath(path))
+	path, err = ParsePath("44h/195h/0h/0/0")
+	require.Nil(t, err)
+	require.Equal(t, DefaultPath, path)
+
+	for _, invalid := range []string{"", "m/44'/x", "m/44'/195'/2147483648'", "1/2/3/4/5/6/7/8/9/10/11"} {
+		_, err = ParsePath(invalid)
+		require.NotNil(t, err, invalid)
+	}
+}
+
+func TestGetAddress(t *testing.T) {
+	app := useFakeApp(t)
+	addr, err := GetAddress()
+	require.Nil(t, err)
+	require.Equal(t, app.address(DefaultPath).String(), addr)
+	require.Equal(t, byte(p1NoDisplay), app.apdus[0].P1)
+
+	other, err := GetAddress(WithPath(AccountPath(0, 1)), WithConfirm())
+	require.Nil(t, err)
+	require.Equal(t, app.address(AccountPath(0, 1)).String(), other)
+	require.NotEqual(t, addr, other)
+	require.Equal(t, byte(p1Display), app.apdus[1].P1)
+
+	config, err := GetAppConfiguration()
+	require.Nil(t, err)
+	require.Equal(t, &AppConfig{Version: "v0.5.1", AllowData: true, AllowContract: true}, config)
+}
+
+func TestSignTx(t *testing.T) {
+	app := useFakeApp(t)
+	path := AccountPath(2, 0)
+	for _, size := range []int{100, 600} {
+		raw := bytes.Repeat([]byte{0x42}, size)
+		sig, err := SignTx(raw, WithPath(path))
+		require.Nil(t, err)
+		require.Len(t, sig, signatureSize)
+		require.Equal(t, raw, app.data)
+
+		hash := sha256.Sum256(raw)
+		pub, err := crypto.SigToPub(hash[:], sig)
+		require.Nil(t, err)
+		require.Equal(t, app.address(path), address.PubkeyToAddress(*pub))
+	}
+	// the 600 bytes transaction spans three APDUs
+	last := app.apdus[len(app.apdus)-3:]
+	require.Equal(t, []byte{p1First, p1More, p1Last}, []byte{last[0].P1, last[1].P1, last[2].P1})
+	for _, apdu := range app.apdus {
+		require.LessOrEqual(t, len(apdu.Payload), packetSize)
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	app := useFakeApp(t)
+	for _, msg := range [][]byte{[]byte("hello TRON"), bytes.Repeat([]byte{'a'}, 400)} {
+		sig, err := SignMessage(msg)
+		require.Nil(t, err)
+		pub, err := crypto.SigToPub(keystore.TextHash(msg), sig)
+		require.Nil(t, err)
+		require.Equal(t, app.address(DefaultPath), address.PubkeyToAddress(*pub))
+	}
+}
+
+func TestDeviceErrors(t *testing.T) {
+	app := useFakeApp(t)
+	for status, expected := range map[uint16]error{
+		0x6985: ErrUserRejected,
+		0x5515: ErrLocked,
+		0x6e00: ErrWrongApp,
+	} {
+		app.status = status
+		_, err := SignTx([]byte{1})
+		require.True(t, errors.Is(err, expected))
+		var code ErrCode
+		require.True(t, errors.As(err, &code))
+		require.Equal(t, ErrCode(status), code)
+	}
+	app.status = 0x6f00
+	_, err := GetAddress()
+	require.EqualError(t, err, "ledger: error code 0x6f00")
+}
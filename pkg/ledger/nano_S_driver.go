@@ -7,19 +7,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
-
-	"github.com/zondax/hid"
 )
 
 const (
 	signatureSize int = 65
 	packetSize    int = 255
+	// chunkSize transaction bytes sent per APDU
+	chunkSize int = 250
 )
 
 var DEBUG bool
 
 type hidFramer struct {
-	rw  io.ReadWriter
+	rw  io.ReadWriteCloser
 	seq uint16
 	buf [64]byte
 	pos int
@@ -37,11 +37,27 @@ type apduFramer struct {
 	buf [2]byte // to read APDU length prefix
 }
 
+// Transport carries APDUs to the TRON app, over HID to a device or over
+// TCP to the Speculos emulator
+type Transport interface {
+	// Exchange send an APDU and return the response ending with its status word
+	Exchange(apdu APDU) ([]byte, error)
+	Close() error
+}
+
 type NanoS struct {
-	device *apduFramer
+	device Transport
 }
 
-type ErrCode uint16
+// NewNanoS talk to the TRON app through a transport
+func NewNanoS(t Transport) *NanoS {
+	return &NanoS{device: t}
+}
+
+// Close the transport
+func (n *NanoS) Close() error {
+	return n.device.Close()
+}
 
 func (hf *hidFramer) Reset() {
 	hf.seq = 0
@@ -81,7 +97,7 @@ func (hf *hidFramer) Read(p []byte) (int, error) {
 	if n, err := hf.rw.Read(hf.buf[:]); err != nil {
 		return 0, err
 	} else if n != 64 {
-		panic("read less than 64 bytes from HID")
+		return 0, fmt.Errorf("read %d bytes from HID, expected 64", n)
 	}
 	// parse header
 	channelID := binary.BigEndian.Uint16(hf.buf[:2])
@@ -103,16 +119,10 @@ func (hf *hidFramer) Read(p []byte) (int, error) {
 
 func (af *apduFramer) Exchange(apdu APDU) ([]byte, error) {
 	if len(apdu.Payload) > packetSize {
-		panic("APDU payload cannot exceed 255 bytes")
+		return nil, fmt.Errorf("APDU payload cannot exceed %d bytes", packetSize)
 	}
 	af.hf.Reset()
-	data := append([]byte{
-		apdu.CLA,
-		apdu.INS,
-		apdu.P1, apdu.P2,
-		byte(len(apdu.Payload)),
-	}, apdu.Payload...)
-	if _, err := af.hf.Write(data); err != nil {
+	if _, err := af.hf.Write(apdu.encode()); err != nil {
 		return nil, err
 	}
 
@@ -130,16 +140,21 @@ func (af *apduFramer) Exchange(apdu APDU) ([]byte, error) {
 	return resp, err
 }
 
-func (c ErrCode) Error() string {
-	return fmt.Sprintf("Error code 0x%x", uint16(c))
+func (af *apduFramer) Close() error {
+	return af.hf.rw.Close()
 }
 
-const codeSuccess = 0x9000
-const codeUserRejected = 0x6985
-const codeInvalidParam = 0x6b01
+// encode APDU bytes, payload prefixed by its length
+func (apdu APDU) encode() []byte {
+	return append([]byte{
+		apdu.CLA,
+		apdu.INS,
+		apdu.P1, apdu.P2,
+		byte(len(apdu.Payload)),
+	}, apdu.Payload...)
+}
 
-var errUserRejected = errors.New("user denied request")
-var errInvalidParam = errors.New("invalid request parameters")
+const codeSuccess = 0x9000
 
 func (n *NanoS) Exchange(cmd byte, p1, p2 byte, data []byte) (resp []byte, err error) {
 	resp, err = n.device.Exchange(APDU{
@@ -155,108 +170,173 @@ func (n *NanoS) Exchange(cmd byte, p1, p2 byte, data []byte) (resp []byte, err e
 		return nil, errors.New("APDU response missing status code")
 	}
 	code := binary.BigEndian.Uint16(resp[len(resp)-2:])
-	resp = resp[:len(resp)-2]
-	switch code {
-	case codeSuccess:
-		err = nil
-	case codeUserRejected:
-		err = errUserRejected
-	case codeInvalidParam:
-		err = errInvalidParam
-	default:
-		err = ErrCode(code)
+	if code != codeSuccess {
+		return nil, ErrCode(code)
 	}
-	return
+	return resp[:len(resp)-2], nil
 }
 
 const (
-	cmdGetVersion   = 0x01
-	cmdGetPublicKey = 0x02
-	cmdSignStaking  = 0x04
-	cmdSignTx       = 0x08
-
-	p1First = 0x0
-	p1More  = 0x80
-
-	p2DisplayAddress = 0x00
-	p2DisplayHash    = 0x00
-	p2SignHash       = 0x01
-	p2Finish         = 0x02
+	cmdGetAppConfiguration = 0x01
+	cmdGetPublicKey        = 0x02
+	cmdSignTx              = 0x04
+	cmdSignPersonalMessage = 0x08
+
+	p1NoDisplay = 0x00
+	p1Display   = 0x01
+
+	p1Single = 0x10
+	p1First  = 0x00
+	p1More   = 0x80
+	p1Last   = 0x90
 )
 
+// AppConfig TRON app version and settings
+type AppConfig struct {
+	Version string `json:"version"`
+	// AllowData transactions with a memo can be signed
+	AllowData bool `json:"allowData"`
+	// AllowContract smart contract calls can be signed
+	AllowContract bool `json:"allowContract"`
+	// TruncateAddress addresses are shown truncated
+	TruncateAddress bool `json:"truncateAddress"`
+	// SignByHash transactions the app cannot parse can be signed by hash
+	SignByHash bool `json:"signByHash"`
+}
+
+// AppConfiguration return the TRON app version and settings
+func (n *NanoS) AppConfiguration() (*AppConfig, error) {
+	resp, err := n.Exchange(cmdGetAppConfiguration, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	} else if len(resp) < 4 {
+		return nil, errors.New("app configuration has wrong length")
+	}
+	return &AppConfig{
+		Version:         fmt.Sprintf("v%d.%d.%d", resp[1], resp[2], resp[3]),
+		AllowData:       resp[0]&0x01 != 0,
+		AllowContract:   resp[0]&0x02 != 0,
+		TruncateAddress: resp[0]&0x04 != 0,
+		SignByHash:      resp[0]&0x08 != 0,
+	}, nil
+}
+
 // GetVersion return  app version
 func (n *NanoS) GetVersion() (version string, err error) {
-	resp, err := n.Exchange(cmdGetVersion, 0, 0, nil)
+	config, err := n.AppConfiguration()
 	if err != nil {
 		return "", err
-	} else if len(resp) != 3 {
-		return "", errors.New("version has wrong length")
 	}
-	return fmt.Sprintf("v%d.%d.%d", resp[0], resp[1], resp[2]), nil
+	return config.Version, nil
 }
 
-// GetAddress return address from path
+// GetAddress return the base58 address of the first account
 func (n *NanoS) GetAddress() (addr string, err error) {
-	resp, err := n.Exchange(cmdGetPublicKey, 0, p2DisplayAddress, []byte{})
+	return n.Address(DefaultPath, false)
+}
+
+// Address return the base58 address at a BIP44 path, shown on the device
+// for the user to confirm when confirm is set
+func (n *NanoS) Address(path []uint32, confirm bool) (string, error) {
+	var p1 byte = p1NoDisplay
+	if confirm {
+		p1 = p1Display
+	}
+	resp, err := n.Exchange(cmdGetPublicKey, p1, 0, encodePath(path))
 	if err != nil {
 		return "", err
 	}
-
-	var pubkey [42]byte
-	if copy(pubkey[:], resp) != len(pubkey) {
-		return "", errors.New("pubkey has wrong length")
+	// public key length, public key, address length, address
+	if len(resp) < 1 || len(resp) < 2+int(resp[0]) {
+		return "", errors.New("public key response has wrong length")
+	}
+	addrStart := 2 + int(resp[0])
+	addrLen := int(resp[addrStart-1])
+	if len(resp) < addrStart+addrLen {
+		return "", errors.New("address has wrong length")
 	}
-	return string(pubkey[:]), nil
+	return string(resp[addrStart : addrStart+addrLen]), nil
 }
 
-// SignTxn sign a TX
-func (n *NanoS) SignTxn(txn []byte) (sig [signatureSize]byte, err error) {
-	var resp []byte
+// SignTxn sign the raw data of a TX with the first account, the app shows
+// the transaction for confirmation
+func (n *NanoS) SignTxn(txn []byte) ([]byte, error) {
+	return n.SignTxnAt(DefaultPath, txn)
+}
 
-	var p1 byte = p1More
-	resp, err = n.Exchange(cmdSignTx, p1, p2SignHash, txn)
-	if err != nil {
-		return [signatureSize]byte{}, err
+// SignTxnAt sign the raw data of a TX with the account at a BIP44 path
+func (n *NanoS) SignTxnAt(path []uint32, txn []byte) ([]byte, error) {
+	chunks := split(path, txn)
+	var resp []byte
+	for i, chunk := range chunks {
+		var p1 byte
+		switch {
+		case len(chunks) == 1:
+			p1 = p1Single
+		case i == 0:
+			p1 = p1First
+		case i == len(chunks)-1:
+			p1 = p1Last
+		default:
+			p1 = p1More
+		}
+		var err error
+		if resp, err = n.Exchange(cmdSignTx, p1, 0, chunk); err != nil {
+			return nil, err
+		}
 	}
+	return signature(resp)
+}
 
-	copy(sig[:], resp)
+// SignMessage sign a TIP-191 personal message with the account at a BIP44
+// path, the app hashes it with the "\x19TRON Signed Message:\n" prefix
+func (n *NanoS) SignMessage(path []uint32, msg []byte) ([]byte, error) {
+	packed := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(packed, uint32(len(msg)))
+	copy(packed[4:], msg)
 
-	if copy(sig[:], resp) != len(sig) {
-		return [signatureSize]byte{}, errors.New("signature has wrong length")
+	var resp []byte
+	for i, chunk := range split(path, packed) {
+		var p1 byte = p1First
+		if i > 0 {
+			p1 = p1More
+		}
+		var err error
+		if resp, err = n.Exchange(cmdSignPersonalMessage, p1, 0, chunk); err != nil {
+			return nil, err
+		}
 	}
-	return
+	return signature(resp)
 }
 
-// OpenNanoS start process
-func OpenNanoS() (*NanoS, error) {
-	const (
-		ledgerVendorID = 0x2c97
-		// new device ID for firmware 1.6.0
-		ledgerNanoSProductID = 0x1011
-		// ledgerNanoSProductID = 0x0001
-		//ledgerUsageID        = 0xffa0
-	)
-
-	// search for Nano S
-	devices := hid.Enumerate(ledgerVendorID, ledgerNanoSProductID)
-	if len(devices) == 0 {
-		return nil, errors.New("Nano S not detected")
-	} else if len(devices) > 1 {
-		return nil, errors.New("Unexpected error -- Is the one wallet app running?")
+// split data into APDU payloads, the first one carrying the path
+func split(path []uint32, data []byte) [][]byte {
+	encoded := encodePath(path)
+	first := chunkSize - len(encoded)
+	if first > len(data) {
+		first = len(data)
 	}
-
-	// open the device
-	device, err := devices[0].Open()
-	if err != nil {
-		return nil, err
+	chunks := [][]byte{append(encoded, data[:first]...)}
+	for rest := data[first:]; len(rest) > 0; {
+		size := chunkSize
+		if size > len(rest) {
+			size = len(rest)
+		}
+		chunks = append(chunks, rest[:size])
+		rest = rest[size:]
 	}
+	return chunks
+}
 
-	// wrap raw device I/O in HID+APDU protocols
-	return &NanoS{
-		device: &apduFramer{
-			hf: &hidFramer{
-				rw: device,
-			},
-		},
-	}, nil
+// signature 65 bytes signature of a response, recovery id as 0 or 1 like
+// software signatures
+func signature(resp []byte) ([]byte, error) {
+	if len(resp) < signatureSize {
+		return nil, errors.New("signature has wrong length")
+	}
+	sig := append([]byte{}, resp[:signatureSize]...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return sig, nil
 }
@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	hardened = 0x80000000
+	// maxPathLength path components the TRON app accepts
+	maxPathLength = 10
+)
+
+// DefaultPath BIP44 path of the first TRON account, m/44'/195'/0'/0/0
+var DefaultPath = AccountPath(0, 0)
+
+// AccountPath BIP44 path m/44'/195'/account'/0/index
+func AccountPath(account, index uint32) []uint32 {
+	return []uint32{44 | hardened, 195 | hardened, account | hardened, 0, index}
+}
+
+// ParsePath BIP44 path such as "m/44'/195'/0'/0/0", hardened components
+// marked with ' or h
+func ParsePath(s string) ([]uint32, error) {
+	components := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "m/"), "/")
+	if len(components) > maxPathLength {
+		return nil, fmt.Errorf("invalid path %q: more than %d components", s, maxPathLength)
+	}
+	path := make([]uint32, len(components))
+	for i, c := range components {
+		var offset uint32
+		if strings.HasSuffix(c, "'") || strings.HasSuffix(c, "h") {
+			offset = hardened
+			c = c[:len(c)-1]
+		}
+		n, err := strconv.ParseUint(c, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %v", s, err)
+		}
+		path[i] = uint32(n) + offset
+	}
+	return path, nil
+}
+
+// FormatPath path as "m/44'/195'/0'/0/0"
+func FormatPath(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, p := range path {
+		if p >= hardened {
+			fmt.Fprintf(&b, "/%d'", p-hardened)
+		} else {
+			fmt.Fprintf(&b, "/%d", p)
+		}
+	}
+	return b.String()
+}
+
+// encodePath BIP44 path as sent to the app
+func encodePath(path []uint32) []byte {
+	b := make([]byte, 1+4*len(path))
+	b[0] = byte(len(path))
+	for i, p := range path {
+		binary.BigEndian.PutUint32(b[1+4*i:], p)
+	}
+	return b
+}
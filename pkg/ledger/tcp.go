@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+const (
+	// ProxyAddressEnv environment variable with the host:port of a Speculos
+	// APDU server, used instead of a HID device when no device is selected
+	ProxyAddressEnv = "LEDGER_PROXY_ADDRESS"
+	// SpeculosPort default APDU port of Speculos
+	SpeculosPort = 9999
+
+	tcpScheme   = "tcp://"
+	dialTimeout = 5 * time.Second
+)
+
+// tcpTransport APDUs over TCP as served by Speculos: each message is
+// prefixed by its 4 bytes big endian length, the status word of a response
+// follows the message and is not counted in the length
+type tcpTransport struct {
+	conn net.Conn
+}
+
+// DialTCP connect to the TRON app running in Speculos, the port defaults
+// to SpeculosPort
+func DialTCP(addr string) (*NanoS, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, fmt.Sprint(SpeculosPort))
+	}
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("ledger: %v", err)
+	}
+	return NewNanoS(&tcpTransport{conn: conn}), nil
+}
+
+// proxyDevice device path of the emulator set by ProxyAddressEnv
+func proxyDevice() string {
+	if addr := os.Getenv(ProxyAddressEnv); len(addr) > 0 {
+		return tcpScheme + addr
+	}
+	return ""
+}
+
+func (t *tcpTransport) Exchange(apdu APDU) ([]byte, error) {
+	if len(apdu.Payload) > packetSize {
+		return nil, fmt.Errorf("APDU payload cannot exceed %d bytes", packetSize)
+	}
+	data := apdu.encode()
+	if DEBUG {
+		fmt.Println("TCP <=", hex.EncodeToString(data))
+	}
+	msg := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(msg, uint32(len(data)))
+	copy(msg[4:], data)
+	if _, err := t.conn.Write(msg); err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(t.conn, size[:]); err != nil {
+		return nil, err
+	}
+	respLen := binary.BigEndian.Uint32(size[:])
+	if respLen > 0xffff {
+		return nil, fmt.Errorf("APDU response of %d bytes", respLen)
+	}
+	// response and status word
+	resp := make([]byte, respLen+2)
+	_, err := io.ReadFull(t.conn, resp)
+	if DEBUG {
+		fmt.Println("TCP =>", hex.EncodeToString(resp))
+	}
+	return resp, err
+}
+
+func (t *tcpTransport) Close() error {
+	return t.conn.Close()
+}
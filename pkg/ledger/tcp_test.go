@@ -0,0 +1,121 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// serveSpeculos answer APDUs framed like the Speculos APDU server with app
+func serveSpeculos(t *testing.T, app *fakeApp) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var size [4]byte
+			if _, err := io.ReadFull(conn, size[:]); err != nil {
+				return
+			}
+			data := make([]byte, binary.BigEndian.Uint32(size[:]))
+			if _, err := io.ReadFull(conn, data); err != nil {
+				return
+			}
+			resp, _ := app.Exchange(APDU{CLA: data[0], INS: data[1], P1: data[2], P2: data[3], Payload: data[5:]})
+			msg := make([]byte, 4, 4+len(resp))
+			binary.BigEndian.PutUint32(msg, uint32(len(resp)-2))
+			if _, err := conn.Write(append(msg, resp...)); err != nil {
+				return
+			}
+		}
+	}()
+	return l.Addr().String()
+}
+
+// useSpeculos select the emulator at addr through ProxyAddressEnv
+func useSpeculos(t *testing.T, addr string) {
+	t.Setenv(ProxyAddressEnv, addr)
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if n, ok := opened[tcpScheme+addr]; ok {
+			n.Close()
+			delete(opened, tcpScheme+addr)
+		}
+	})
+}
+
+func TestTCPTransport(t *testing.T) {
+	app := newFakeApp()
+	useSpeculos(t, serveSpeculos(t, app))
+
+	addr, err := GetAddress(WithPath(AccountPath(0, 3)))
+	require.Nil(t, err)
+	require.Equal(t, app.address(AccountPath(0, 3)).String(), addr)
+
+	config, err := GetAppConfiguration()
+	require.Nil(t, err)
+	require.Equal(t, "v0.5.1", config.Version)
+
+	raw := bytes.Repeat([]byte{0x42}, 600)
+	sig, err := SignTx(raw)
+	require.Nil(t, err)
+	hash := sha256.Sum256(raw)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	require.Nil(t, err)
+	require.Equal(t, app.address(DefaultPath), address.PubkeyToAddress(*pub))
+
+	app.status = 0x6985
+	_, err = SignTx(raw)
+	require.True(t, errors.Is(err, ErrUserRejected))
+}
+
+func TestOpenTCP(t *testing.T) {
+	app := newFakeApp()
+	n, err := Open(tcpScheme + serveSpeculos(t, app))
+	require.Nil(t, err)
+	defer n.Close()
+	addr, err := n.GetAddress()
+	require.Nil(t, err)
+	require.Equal(t, app.address(DefaultPath).String(), addr)
+
+	_, err = DialTCP("127.0.0.1:1")
+	require.NotNil(t, err)
+}
+
+// TestSpeculos derive addresses with the TRON app running in Speculos, e.g.
+//
+//	speculos --model nanos --display headless apps/tron.elf &
+//	LEDGER_PROXY_ADDRESS=127.0.0.1:9999 go test ./pkg/ledger -run Speculos
+func TestSpeculos(t *testing.T) {
+	if len(os.Getenv(ProxyAddressEnv)) == 0 {
+		t.Skip(ProxyAddressEnv + " not set")
+	}
+	useSpeculos(t, os.Getenv(ProxyAddressEnv))
+
+	config, err := GetAppConfiguration()
+	require.Nil(t, err)
+	require.NotEmpty(t, config.Version)
+
+	first, err := GetAddress()
+	require.Nil(t, err)
+	_, err = address.Base58ToAddress(first)
+	require.Nil(t, err)
+	second, err := GetAddress(WithPath(AccountPath(0, 1)))
+	require.Nil(t, err)
+	require.NotEqual(t, first, second)
+}
@@ -0,0 +1,64 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+)
+
+// DefaultRefBlockTTL how long a reference block is reused. Transactions
+// built from a cached reference expire that much sooner.
+const DefaultRefBlockTTL = 10 * time.Second
+
+// HeadSource returns the chain head, *client.Client implements it
+type HeadSource interface {
+	GetNowBlock(ctx context.Context) (*api.BlockExtention, error)
+}
+
+// RefBlockCache keeps the reference block, refreshed from the head once
+// older than its TTL. It is safe for concurrent use.
+type RefBlockCache struct {
+	source  HeadSource
+	ttl     time.Duration
+	mu      sync.Mutex
+	ref     RefBlock
+	fetched time.Time
+}
+
+// NewRefBlockCache create cache refreshed from source, which may be nil
+// when references are only provided with Set
+func NewRefBlockCache(source HeadSource, ttl time.Duration) *RefBlockCache {
+	return &RefBlockCache{source: source, ttl: ttl}
+}
+
+// Get return the cached reference, fetching the head when stale
+func (c *RefBlockCache) Get(ctx context.Context) (RefBlock, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.ref.ID) > 0 && (c.source == nil || time.Since(c.fetched) < c.ttl) {
+		return c.ref, nil
+	}
+	if c.source == nil {
+		return RefBlock{}, fmt.Errorf("no reference block")
+	}
+	head, err := c.source.GetNowBlock(ctx)
+	if err != nil {
+		return RefBlock{}, err
+	}
+	ref, err := RefBlockFrom(head)
+	if err != nil {
+		return RefBlock{}, err
+	}
+	c.ref, c.fetched = ref, time.Now()
+	return ref, nil
+}
+
+// Set replace the reference, such as one carried to an air-gapped machine
+func (c *RefBlockCache) Set(ref RefBlock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ref, c.fetched = ref, time.Now()
+}
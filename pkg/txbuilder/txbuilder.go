@@ -0,0 +1,166 @@
+// Package txbuilder builds transactions locally, without asking a node to
+// create them. Raw data matches what a java-tron node returns for the same
+// contract, reference block and timestamp, so transaction ids are equal.
+//
+//	refs := txbuilder.NewRefBlockCache(conn, txbuilder.DefaultRefBlockTTL)
+//	ref, err := refs.Get(ctx)
+//	tx, err := txbuilder.New().Build(ref, &core.TransferContract{...})
+package txbuilder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/api"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// DefaultExpiration after the reference block time, as java-tron
+	DefaultExpiration = 60 * time.Second
+	// MaxExpiration after the reference block time accepted by java-tron
+	MaxExpiration = 24 * time.Hour
+)
+
+// RefBlock block a transaction references (TAPOS)
+type RefBlock struct {
+	Number int64  `json:"number"`
+	ID     []byte `json:"id"`
+	// Timestamp block time in milliseconds, the base of expiration
+	Timestamp int64 `json:"timestamp"`
+}
+
+// RefBlockFrom reference of a block as returned by GetNowBlock2
+func RefBlockFrom(block *api.BlockExtention) (RefBlock, error) {
+	raw := block.GetBlockHeader().GetRawData()
+	if len(block.GetBlockid()) != sha256.Size || raw == nil {
+		return RefBlock{}, fmt.Errorf("invalid reference block")
+	}
+	return RefBlock{Number: raw.GetNumber(), ID: block.GetBlockid(), Timestamp: raw.GetTimestamp()}, nil
+}
+
+// Bytes ref_block_bytes, bytes 6 and 7 of the block number
+func (r RefBlock) Bytes() []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(r.Number))
+	return b[6:8]
+}
+
+// Hash ref_block_hash, bytes 8 to 15 of the block id
+func (r RefBlock) Hash() []byte {
+	return r.ID[8:16]
+}
+
+// Builder creates unsigned transactions
+type Builder struct {
+	// Expiration after the reference block time, DefaultExpiration by
+	// default. Cold signing needs longer, up to MaxExpiration.
+	Expiration time.Duration
+	// Now clock of transaction timestamps, time.Now by default
+	Now func() time.Time
+}
+
+// New create builder
+func New(options ...func(*Builder)) *Builder {
+	b := &Builder{
+		Expiration: DefaultExpiration,
+		Now:        time.Now,
+	}
+	for _, option := range options {
+		option(b)
+	}
+	return b
+}
+
+// WithFeeLimit set the energy fee limit in SUN of contract calls
+func WithFeeLimit(feeLimit int64) func(*core.TransactionRaw) {
+	return func(raw *core.TransactionRaw) {
+		raw.FeeLimit = feeLimit
+	}
+}
+
+// WithPermissionID sign with an account permission other than owner
+func WithPermissionID(id int32) func(*core.TransactionRaw) {
+	return func(raw *core.TransactionRaw) {
+		for _, c := range raw.Contract {
+			c.PermissionId = id
+		}
+	}
+}
+
+// WithMemo set the memo, raw_data.data, charged the memo fee when not empty
+func WithMemo(memo string) func(*core.TransactionRaw) {
+	return func(raw *core.TransactionRaw) {
+		raw.Data = []byte(memo)
+	}
+}
+
+// Build wrap contract, any contract message such as *core.TransferContract,
+// into an unsigned transaction referencing ref
+func (b *Builder) Build(ref RefBlock, contract proto.Message, options ...func(*core.TransactionRaw)) (*api.TransactionExtention, error) {
+	kind, err := ContractType(contract)
+	if err != nil {
+		return nil, err
+	}
+	if len(ref.ID) != sha256.Size {
+		return nil, fmt.Errorf("invalid reference block id")
+	}
+	if b.Expiration <= 0 || b.Expiration > MaxExpiration {
+		return nil, fmt.Errorf("expiration %s out of range", b.Expiration)
+	}
+	param := &anypb.Any{}
+	if err := anypb.MarshalFrom(param, contract, proto.MarshalOptions{Deterministic: true}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if b.Now != nil {
+		now = b.Now
+	}
+	raw := &core.TransactionRaw{
+		RefBlockBytes: ref.Bytes(),
+		RefBlockHash:  ref.Hash(),
+		Expiration:    ref.Timestamp + b.Expiration.Milliseconds(),
+		Timestamp:     now().UnixMilli(),
+		Contract: []*core.Transaction_Contract{{
+			Type:      kind,
+			Parameter: param,
+		}},
+	}
+	for _, option := range options {
+		option(raw)
+	}
+
+	tx := &api.TransactionExtention{
+		Transaction: &core.Transaction{RawData: raw},
+		Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+	}
+	if tx.Txid, err = TxID(tx.Transaction); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// ContractType of a contract message, named after it
+func ContractType(contract proto.Message) (core.Transaction_Contract_ContractType, error) {
+	name := string(contract.ProtoReflect().Descriptor().Name())
+	kind, ok := core.Transaction_Contract_ContractType_value[name]
+	if !ok {
+		return 0, fmt.Errorf("%s is not a transaction contract", name)
+	}
+	return core.Transaction_Contract_ContractType(kind), nil
+}
+
+// TxID hash of the transaction raw data
+func TxID(tx *core.Transaction) ([]byte, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+	return hash[:], nil
+}
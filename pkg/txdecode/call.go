@@ -0,0 +1,132 @@
+package txdecode
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Call decoded smart contract call data
+type Call struct {
+	// Selector first 4 bytes of the call data in hex
+	Selector string `json:"selector"`
+	// Method signature, such as "transfer(address,uint256)", empty when the
+	// contract ABI is unknown or has no matching function
+	Method string `json:"method,omitempty"`
+	Args   []*Arg `json:"args,omitempty"`
+	// Error why arguments could not be decoded
+	Error string `json:"error,omitempty"`
+}
+
+// Arg decoded call argument, addresses in base58 and integers in decimal
+type Arg struct {
+	Name  string      `json:"name,omitempty"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func (d *Decoder) decodeCall(ctx context.Context, trigger *core.TriggerSmartContract) *Call {
+	data := trigger.GetData()
+	call := &Call{Selector: common.BytesToHexString(data[:4])[2:]}
+	abi := d.abi(ctx, address.Address(trigger.GetContractAddress()).String())
+	entry, signature := function(abi, data[:4])
+	if entry == nil {
+		return call
+	}
+	call.Method = signature
+	args, err := DecodeArgs(entry.GetInputs(), data[4:])
+	if err != nil {
+		call.Error = err.Error()
+		return call
+	}
+	call.Args = args
+	return call
+}
+
+// function ABI entry of a selector with its signature
+func function(abi *core.SmartContract_ABI, selector []byte) (*core.SmartContract_ABI_Entry, string) {
+	for _, entry := range abi.GetEntrys() {
+		if entry.GetType() != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		types := make([]string, len(entry.GetInputs()))
+		for i, input := range entry.GetInputs() {
+			types[i] = input.GetType()
+		}
+		signature := fmt.Sprintf("%s(%s)", entry.GetName(), strings.Join(types, ","))
+		if bytes.Equal(common.Keccak256([]byte(signature))[:4], selector) {
+			return entry, signature
+		}
+	}
+	return nil, ""
+}
+
+// DecodeArgs unpack ABI encoded values of params
+func DecodeArgs(params []*core.SmartContract_ABI_Entry_Param, data []byte) ([]*Arg, error) {
+	arguments := make(eABI.Arguments, 0, len(params))
+	for _, param := range params {
+		typ := param.GetType()
+		// TRC10 token ids are uint256 on the TVM
+		typ = strings.ReplaceAll(typ, "trcToken", "uint256")
+		ty, err := eABI.NewType(typ, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %s: %v", param.GetType(), err)
+		}
+		arguments = append(arguments, eABI.Argument{Name: param.GetName(), Type: ty})
+	}
+	values, err := arguments.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]*Arg, len(values))
+	for i, value := range values {
+		args[i] = &Arg{
+			Name:  params[i].GetName(),
+			Type:  params[i].GetType(),
+			Value: argValue(value),
+		}
+	}
+	return args, nil
+}
+
+// argValue JSON friendly ABI value
+func argValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case eCommon.Address:
+		return address.Address(append([]byte{address.TronBytePrefix}, value.Bytes()...)).String()
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return common.BytesToHexString(value)
+	case string, bool:
+		return value
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return common.BytesToHexString(b)
+		}
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = argValue(rv.Index(i).Interface())
+		}
+		return values
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprint(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(rv.Uint())
+	}
+	return v
+}
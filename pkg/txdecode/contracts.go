@@ -0,0 +1,72 @@
+package txdecode
+
+import (
+	"fmt"
+
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// contractTypes message carried by each contract type
+var contractTypes = map[core.Transaction_Contract_ContractType]proto.Message{
+	core.Transaction_Contract_AccountCreateContract:           &core.AccountCreateContract{},
+	core.Transaction_Contract_TransferContract:                &core.TransferContract{},
+	core.Transaction_Contract_TransferAssetContract:           &core.TransferAssetContract{},
+	core.Transaction_Contract_VoteAssetContract:               &core.VoteAssetContract{},
+	core.Transaction_Contract_VoteWitnessContract:             &core.VoteWitnessContract{},
+	core.Transaction_Contract_WitnessCreateContract:           &core.WitnessCreateContract{},
+	core.Transaction_Contract_WitnessUpdateContract:           &core.WitnessUpdateContract{},
+	core.Transaction_Contract_AssetIssueContract:              &core.AssetIssueContract{},
+	core.Transaction_Contract_ParticipateAssetIssueContract:   &core.ParticipateAssetIssueContract{},
+	core.Transaction_Contract_AccountUpdateContract:           &core.AccountUpdateContract{},
+	core.Transaction_Contract_FreezeBalanceContract:           &core.FreezeBalanceContract{},
+	core.Transaction_Contract_UnfreezeBalanceContract:         &core.UnfreezeBalanceContract{},
+	core.Transaction_Contract_WithdrawBalanceContract:         &core.WithdrawBalanceContract{},
+	core.Transaction_Contract_UnfreezeAssetContract:           &core.UnfreezeAssetContract{},
+	core.Transaction_Contract_UpdateAssetContract:             &core.UpdateAssetContract{},
+	core.Transaction_Contract_ProposalCreateContract:          &core.ProposalCreateContract{},
+	core.Transaction_Contract_ProposalApproveContract:         &core.ProposalApproveContract{},
+	core.Transaction_Contract_ProposalDeleteContract:          &core.ProposalDeleteContract{},
+	core.Transaction_Contract_SetAccountIdContract:            &core.SetAccountIdContract{},
+	core.Transaction_Contract_CreateSmartContract:             &core.CreateSmartContract{},
+	core.Transaction_Contract_TriggerSmartContract:            &core.TriggerSmartContract{},
+	core.Transaction_Contract_UpdateSettingContract:           &core.UpdateSettingContract{},
+	core.Transaction_Contract_ExchangeCreateContract:          &core.ExchangeCreateContract{},
+	core.Transaction_Contract_ExchangeInjectContract:          &core.ExchangeInjectContract{},
+	core.Transaction_Contract_ExchangeWithdrawContract:        &core.ExchangeWithdrawContract{},
+	core.Transaction_Contract_ExchangeTransactionContract:     &core.ExchangeTransactionContract{},
+	core.Transaction_Contract_UpdateEnergyLimitContract:       &core.UpdateEnergyLimitContract{},
+	core.Transaction_Contract_AccountPermissionUpdateContract: &core.AccountPermissionUpdateContract{},
+	core.Transaction_Contract_ClearABIContract:                &core.ClearABIContract{},
+	core.Transaction_Contract_UpdateBrokerageContract:         &core.UpdateBrokerageContract{},
+	core.Transaction_Contract_ShieldedTransferContract:        &core.ShieldedTransferContract{},
+	core.Transaction_Contract_MarketSellAssetContract:         &core.MarketSellAssetContract{},
+	core.Transaction_Contract_MarketCancelOrderContract:       &core.MarketCancelOrderContract{},
+	core.Transaction_Contract_FreezeBalanceV2Contract:         &core.FreezeBalanceV2Contract{},
+	core.Transaction_Contract_UnfreezeBalanceV2Contract:       &core.UnfreezeBalanceV2Contract{},
+	core.Transaction_Contract_WithdrawExpireUnfreezeContract:  &core.WithdrawExpireUnfreezeContract{},
+	core.Transaction_Contract_DelegateResourceContract:        &core.DelegateResourceContract{},
+	core.Transaction_Contract_UnDelegateResourceContract:      &core.UnDelegateResourceContract{},
+	core.Transaction_Contract_CancelAllUnfreezeV2Contract:     &core.CancelAllUnfreezeV2Contract{},
+}
+
+// Unpack return the typed message of a contract, such as
+// *core.TransferContract. Types without a known message are unpacked from
+// the parameter type URL.
+func Unpack(c *core.Transaction_Contract) (proto.Message, error) {
+	if c.GetParameter() == nil {
+		return nil, fmt.Errorf("%s: no parameter", c.GetType())
+	}
+	if prototype, ok := contractTypes[c.GetType()]; ok {
+		msg := prototype.ProtoReflect().New().Interface()
+		if err := c.GetParameter().UnmarshalTo(msg); err != nil {
+			return nil, fmt.Errorf("%s: %v", c.GetType(), err)
+		}
+		return msg, nil
+	}
+	msg, err := c.GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", c.GetType(), err)
+	}
+	return msg, nil
+}
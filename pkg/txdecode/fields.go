@@ -0,0 +1,152 @@
+package txdecode
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/elleqt/gotron-sdk/pkg/address"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// trxField marks an amount field in SUN
+const trxField = ""
+
+// amountFields amount fields of contract messages and the field holding
+// their token id, trxField for TRX amounts
+var amountFields = map[protoreflect.FullName]map[protoreflect.Name]protoreflect.Name{
+	"protocol.TransferContract":              {"amount": trxField},
+	"protocol.TransferAssetContract":         {"amount": "asset_name"},
+	"protocol.ParticipateAssetIssueContract": {"amount": trxField},
+	"protocol.FreezeBalanceContract":         {"frozen_balance": trxField},
+	"protocol.FreezeBalanceV2Contract":       {"frozen_balance": trxField},
+	"protocol.UnfreezeBalanceV2Contract":     {"unfreeze_balance": trxField},
+	"protocol.DelegateResourceContract":      {"balance": trxField},
+	"protocol.UnDelegateResourceContract":    {"balance": trxField},
+	"protocol.TriggerSmartContract":          {"call_value": trxField, "call_token_value": "token_id"},
+	"protocol.ExchangeCreateContract": {
+		"first_token_balance":  "first_token_id",
+		"second_token_balance": "second_token_id",
+	},
+	"protocol.ExchangeInjectContract":      {"quant": "token_id"},
+	"protocol.ExchangeWithdrawContract":    {"quant": "token_id"},
+	"protocol.ExchangeTransactionContract": {"quant": "token_id"},
+	"protocol.MarketSellAssetContract": {
+		"sell_token_quantity": "sell_token_id",
+		"buy_token_quantity":  "buy_token_id",
+	},
+}
+
+// fields JSON friendly map of a message by protobuf field name, amounts
+// are decoded on the contract message only
+func (d *Decoder) fields(ctx context.Context, m protoreflect.Message, contract bool) map[string]interface{} {
+	var amounts map[protoreflect.Name]protoreflect.Name
+	if contract {
+		amounts = amountFields[m.Descriptor().FullName()]
+	}
+	result := make(map[string]interface{})
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) && (fd.ContainingOneof() != nil || fd.IsList() || fd.IsMap() ||
+			fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.BytesKind) {
+			continue
+		}
+		if tokenField, ok := amounts[fd.Name()]; ok {
+			raw := m.Get(fd).Int()
+			if tokenField == trxField {
+				result[string(fd.Name())] = trx(raw)
+			} else {
+				result[string(fd.Name())] = d.token(ctx, tokenID(m, tokenField), raw)
+			}
+			continue
+		}
+		result[string(fd.Name())] = d.value(ctx, fd, m.Get(fd))
+	}
+	return result
+}
+
+func (d *Decoder) value(ctx context.Context, fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		values := make([]interface{}, list.Len())
+		for i := range values {
+			values[i] = d.scalar(ctx, fd, list.Get(i))
+		}
+		return values
+	case fd.IsMap():
+		values := make(map[string]interface{})
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			values[k.String()] = d.scalar(ctx, fd.MapValue(), v)
+			return true
+		})
+		return values
+	}
+	return d.scalar(ctx, fd, v)
+}
+
+func (d *Decoder) scalar(ctx context.Context, fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return d.fields(ctx, v.Message(), false)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	case protoreflect.BytesKind:
+		return bytesValue(fd.Name(), v.Bytes())
+	}
+	return v.Interface()
+}
+
+// bytesValue addresses in base58, text as is, anything else in hex
+func bytesValue(name protoreflect.Name, b []byte) string {
+	if len(b) == address.AddressLength && b[0] == address.TronBytePrefix &&
+		strings.HasSuffix(string(name), "address") {
+		return address.Address(b).String()
+	}
+	if isText(b) {
+		return string(b)
+	}
+	return common.BytesToHexString(b)
+}
+
+// Memo transaction memo, text as is or hex when binary
+func Memo(data []byte) string {
+	if isText(data) {
+		return string(data)
+	}
+	return common.BytesToHexString(data)
+}
+
+func isText(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenID TRC10 id held by a bytes or integer field
+func tokenID(m protoreflect.Message, name protoreflect.Name) string {
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil {
+		return ""
+	}
+	if fd.Kind() == protoreflect.BytesKind {
+		return string(m.Get(fd).Bytes())
+	}
+	if id := m.Get(fd).Int(); id > 0 {
+		return strconv.FormatInt(id, 10)
+	}
+	return ""
+}
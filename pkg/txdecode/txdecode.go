@@ -0,0 +1,223 @@
+// Package txdecode turns transactions into typed, JSON friendly values:
+// addresses in base58, TRX amounts in decimal form, TRC10 tokens resolved
+// and smart contract calls decoded against their ABI.
+//
+//	d := txdecode.New(conn)
+//	decoded, err := d.Decode(ctx, tx)
+package txdecode
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// Resolver looks up the chain data decoding needs, *client.Client
+// implements it
+type Resolver interface {
+	GetAssetIssueByID(ctx context.Context, tokenID string) (*core.AssetIssueContract, error)
+	GetContractABI(ctx context.Context, contractAddress string) (*core.SmartContract_ABI, error)
+}
+
+// Transaction decoded transaction
+type Transaction struct {
+	ID         string  `json:"txID"`
+	Timestamp  int64   `json:"timestamp,omitempty"`
+	Expiration int64   `json:"expiration"`
+	FeeLimit   *Amount `json:"feeLimit,omitempty"`
+	// Memo raw_data.data, text as is or hex
+	Memo       string      `json:"memo,omitempty"`
+	Contracts  []*Contract `json:"contracts"`
+	Signatures int         `json:"signatures"`
+}
+
+// Contract decoded transaction contract
+type Contract struct {
+	Type         string `json:"type"`
+	PermissionID int32  `json:"permissionId,omitempty"`
+	// Message typed contract, such as *core.TransferContract
+	Message proto.Message `json:"-"`
+	// Parameter fields of Message by protobuf name
+	Parameter map[string]interface{} `json:"parameter"`
+	// Call smart contract call data of TriggerSmartContract
+	Call *Call `json:"call,omitempty"`
+}
+
+// Amount value of TRX or of a TRC10 token
+type Amount struct {
+	// Raw integer amount, SUN for TRX
+	Raw int64 `json:"raw"`
+	// Value decimal amount, raw when the token precision is unknown
+	Value string `json:"value"`
+	// TokenID TRC10 token, empty for TRX
+	TokenID string `json:"tokenId,omitempty"`
+	Symbol  string `json:"symbol,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// Decoder decodes transactions, caching resolved tokens and ABIs.
+// It is safe for concurrent use.
+type Decoder struct {
+	resolver Resolver
+	mu       sync.Mutex
+	tokens   map[string]*core.AssetIssueContract
+	abis     map[string]*core.SmartContract_ABI
+}
+
+// New create decoder, resolver may be nil to decode offline
+func New(resolver Resolver) *Decoder {
+	return &Decoder{
+		resolver: resolver,
+		tokens:   make(map[string]*core.AssetIssueContract),
+		abis:     make(map[string]*core.SmartContract_ABI),
+	}
+}
+
+// AddABI register the ABI of a base58 contract address, used instead of
+// asking the resolver
+func (d *Decoder) AddABI(contractAddress string, abi *core.SmartContract_ABI) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.abis[contractAddress] = abi
+}
+
+// AddToken register a TRC10 token, used instead of asking the resolver
+func (d *Decoder) AddToken(token *core.AssetIssueContract) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tokens[token.GetId()] = token
+}
+
+// Decode every contract of a transaction
+func (d *Decoder) Decode(ctx context.Context, tx *core.Transaction) (*Transaction, error) {
+	raw := tx.GetRawData()
+	rawData, err := proto.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	id := sha256.Sum256(rawData)
+	decoded := &Transaction{
+		ID:         common.BytesToHexString(id[:])[2:],
+		Timestamp:  raw.GetTimestamp(),
+		Expiration: raw.GetExpiration(),
+		Signatures: len(tx.GetSignature()),
+	}
+	if raw.GetFeeLimit() > 0 {
+		decoded.FeeLimit = trx(raw.GetFeeLimit())
+	}
+	if len(raw.GetData()) > 0 {
+		decoded.Memo = Memo(raw.GetData())
+	}
+	for _, c := range raw.GetContract() {
+		contract, err := d.DecodeContract(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		decoded.Contracts = append(decoded.Contracts, contract)
+	}
+	return decoded, nil
+}
+
+// DecodeContract decode a single transaction contract
+func (d *Decoder) DecodeContract(ctx context.Context, c *core.Transaction_Contract) (*Contract, error) {
+	msg, err := Unpack(c)
+	if err != nil {
+		return nil, err
+	}
+	contract := &Contract{
+		Type:         c.GetType().String(),
+		PermissionID: c.GetPermissionId(),
+		Message:      msg,
+		Parameter:    d.fields(ctx, msg.ProtoReflect(), true),
+	}
+	if trigger, ok := msg.(*core.TriggerSmartContract); ok && len(trigger.GetData()) >= 4 {
+		contract.Call = d.decodeCall(ctx, trigger)
+	}
+	return contract, nil
+}
+
+// trx amount in SUN
+func trx(sun int64) *Amount {
+	return &Amount{Raw: sun, Value: FormatAmount(sun, common.AmountDecimalPoint), Symbol: "TRX"}
+}
+
+// token amount of a TRC10 id, TRX for "_" and empty ids
+func (d *Decoder) token(ctx context.Context, id string, raw int64) *Amount {
+	if len(id) == 0 || id == "_" || id == "0" {
+		return trx(raw)
+	}
+	amount := &Amount{Raw: raw, Value: strconv.FormatInt(raw, 10), TokenID: id}
+	if asset := d.asset(ctx, id); asset != nil {
+		amount.Value = FormatAmount(raw, int(asset.GetPrecision()))
+		amount.Symbol = string(asset.GetAbbr())
+		amount.Name = string(asset.GetName())
+	}
+	return amount
+}
+
+func (d *Decoder) asset(ctx context.Context, id string) *core.AssetIssueContract {
+	d.mu.Lock()
+	asset, ok := d.tokens[id]
+	d.mu.Unlock()
+	if ok || d.resolver == nil {
+		return asset
+	}
+	asset, err := d.resolver.GetAssetIssueByID(ctx, id)
+	if err != nil {
+		// may be transient, look it up again next time
+		return nil
+	}
+	if asset.GetId() != id {
+		// the node answers an empty asset for unknown ids
+		asset = nil
+	}
+	d.mu.Lock()
+	d.tokens[id] = asset
+	d.mu.Unlock()
+	return asset
+}
+
+func (d *Decoder) abi(ctx context.Context, contractAddress string) *core.SmartContract_ABI {
+	d.mu.Lock()
+	abi, ok := d.abis[contractAddress]
+	d.mu.Unlock()
+	if ok || d.resolver == nil {
+		return abi
+	}
+	abi, err := d.resolver.GetContractABI(ctx, contractAddress)
+	if err != nil {
+		return nil
+	}
+	d.mu.Lock()
+	d.abis[contractAddress] = abi
+	d.mu.Unlock()
+	return abi
+}
+
+// FormatAmount render an integer amount with decimals, trailing zeros
+// trimmed: FormatAmount(1500000, 6) is "1.5"
+func FormatAmount(raw int64, decimals int) string {
+	s := strconv.FormatInt(raw, 10)
+	if decimals <= 0 {
+		return s
+	}
+	sign := ""
+	if raw < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+	integer, fraction := s[:len(s)-decimals], strings.TrimRight(s[len(s)-decimals:], "0")
+	if len(fraction) == 0 {
+		return sign + integer
+	}
+	return fmt.Sprintf("%s%s.%s", sign, integer, fraction)
+}
@@ -0,0 +1,246 @@
+package txdecode_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/elleqt/gotron-sdk/pkg/abi"
+	"github.com/elleqt/gotron-sdk/pkg/common"
+	"github.com/elleqt/gotron-sdk/pkg/contract"
+	"github.com/elleqt/gotron-sdk/pkg/proto/core"
+	"github.com/elleqt/gotron-sdk/pkg/txdecode"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	owner     = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	recipient = "TUoHaVjx7n5xz8LwPRDckgFrDWhMhuSuJM"
+	usdt      = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+)
+
+const trc20ABI = `[{"name":"transfer","type":"function","stateMutability":"nonpayable",
+"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],
+"outputs":[{"name":"","type":"bool"}]}]`
+
+type resolver struct {
+	calls int
+	// failures calls failing before the resolver answers
+	failures int
+}
+
+func (r *resolver) GetAssetIssueByID(_ context.Context, id string) (*core.AssetIssueContract, error) {
+	r.calls++
+	if r.failures > 0 {
+		r.failures--
+		return nil, errors.New("node unavailable")
+	}
+	if id != "1002000" {
+		return &core.AssetIssueContract{}, nil
+	}
+	return &core.AssetIssueContract{Id: id, Name: []byte("BitTorrent"), Abbr: []byte("BTT"), Precision: 6}, nil
+}
+
+func (r *resolver) GetContractABI(_ context.Context, _ string) (*core.SmartContract_ABI, error) {
+	r.calls++
+	if r.failures > 0 {
+		r.failures--
+		return nil, errors.New("node unavailable")
+	}
+	// unknown contracts have no ABI
+	return nil, nil
+}
+
+func mustDecode(t *testing.T, addr string) []byte {
+	b, err := common.DecodeCheck(addr)
+	require.Nil(t, err)
+	return b
+}
+
+func transaction(t *testing.T, typ core.Transaction_Contract_ContractType, msg proto.Message) *core.Transaction {
+	param, err := anypb.New(msg)
+	require.Nil(t, err)
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Expiration: 1700000060000,
+		Timestamp:  1700000000000,
+		FeeLimit:   10_000_000,
+		Contract:   []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+	}}
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	tx := transaction(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: mustDecode(t, owner),
+		ToAddress:    mustDecode(t, recipient),
+		Amount:       1_500_000,
+	})
+	decoded, err := txdecode.New(nil).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Len(t, decoded.ID, 64)
+	require.Equal(t, "10", decoded.FeeLimit.Value)
+	require.Len(t, decoded.Contracts, 1)
+
+	c := decoded.Contracts[0]
+	require.Equal(t, "TransferContract", c.Type)
+	require.IsType(t, &core.TransferContract{}, c.Message)
+	require.Equal(t, owner, c.Parameter["owner_address"])
+	require.Equal(t, recipient, c.Parameter["to_address"])
+	require.Equal(t, &txdecode.Amount{Raw: 1_500_000, Value: "1.5", Symbol: "TRX"}, c.Parameter["amount"])
+
+	_, err = json.Marshal(decoded)
+	require.Nil(t, err)
+}
+
+func TestDecodeTokens(t *testing.T) {
+	r := &resolver{}
+	d := txdecode.New(r)
+	for _, id := range []string{"1002000", "1002000", "1009999"} {
+		tx := transaction(t, core.Transaction_Contract_TransferAssetContract, &core.TransferAssetContract{
+			AssetName:    []byte(id),
+			OwnerAddress: mustDecode(t, owner),
+			ToAddress:    mustDecode(t, recipient),
+			Amount:       2_000_000,
+		})
+		decoded, err := d.Decode(context.Background(), tx)
+		require.Nil(t, err)
+		amount := decoded.Contracts[0].Parameter["amount"].(*txdecode.Amount)
+		require.Equal(t, id, amount.TokenID)
+		if id == "1002000" {
+			require.Equal(t, "2", amount.Value)
+			require.Equal(t, "BTT", amount.Symbol)
+		} else {
+			require.Equal(t, "2000000", amount.Value)
+			require.Empty(t, amount.Symbol)
+		}
+		require.Equal(t, id, decoded.Contracts[0].Parameter["asset_name"])
+	}
+	require.Equal(t, 2, r.calls)
+}
+
+func TestDecodeResolverErrors(t *testing.T) {
+	r := &resolver{failures: 1}
+	d := txdecode.New(r)
+	tx := transaction(t, core.Transaction_Contract_TransferAssetContract, &core.TransferAssetContract{
+		AssetName:    []byte("1002000"),
+		OwnerAddress: mustDecode(t, owner),
+		ToAddress:    mustDecode(t, recipient),
+		Amount:       2_000_000,
+	})
+	call := transaction(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    mustDecode(t, owner),
+		ContractAddress: mustDecode(t, usdt),
+		Data:            []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+
+	// failed lookups are not cached
+	decoded, err := d.Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Empty(t, decoded.Contracts[0].Parameter["amount"].(*txdecode.Amount).Symbol)
+	decoded, err = d.Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "BTT", decoded.Contracts[0].Parameter["amount"].(*txdecode.Amount).Symbol)
+	r.failures = 1
+	_, err = d.Decode(context.Background(), call)
+	require.Nil(t, err)
+	require.Equal(t, 3, r.calls)
+
+	// a contract without ABI is
+	for i := 0; i < 2; i++ {
+		_, err = d.Decode(context.Background(), call)
+		require.Nil(t, err)
+	}
+	require.Equal(t, 4, r.calls)
+}
+
+func TestDecodeEnumsAndMaps(t *testing.T) {
+	tx := transaction(t, core.Transaction_Contract_FreezeBalanceV2Contract, &core.FreezeBalanceV2Contract{
+		OwnerAddress:  mustDecode(t, owner),
+		FrozenBalance: 3_000_000,
+		Resource:      core.ResourceCode_ENERGY,
+	})
+	decoded, err := txdecode.New(nil).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "ENERGY", decoded.Contracts[0].Parameter["resource"])
+	require.Equal(t, "3", decoded.Contracts[0].Parameter["frozen_balance"].(*txdecode.Amount).Value)
+
+	tx = transaction(t, core.Transaction_Contract_ProposalCreateContract, &core.ProposalCreateContract{
+		OwnerAddress: mustDecode(t, owner),
+		Parameters:   map[int64]int64{11: 420},
+	})
+	decoded, err = txdecode.New(nil).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"11": int64(420)}, decoded.Contracts[0].Parameter["parameters"])
+}
+
+func TestDecodeCall(t *testing.T) {
+	data, err := abi.Pack("transfer(address,uint256)", []abi.Param{
+		{"address": recipient},
+		{"uint256": big.NewInt(250_000)},
+	})
+	require.Nil(t, err)
+	tx := transaction(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    mustDecode(t, owner),
+		ContractAddress: mustDecode(t, usdt),
+		Data:            data,
+	})
+
+	r := &resolver{}
+	decoded, err := txdecode.New(r).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	call := decoded.Contracts[0].Call
+	require.Equal(t, "a9059cbb", call.Selector)
+	require.Empty(t, call.Method)
+
+	d := txdecode.New(r)
+	contractABI, err := contract.JSONtoABI(trc20ABI)
+	require.Nil(t, err)
+	d.AddABI(usdt, contractABI)
+	decoded, err = d.Decode(context.Background(), tx)
+	require.Nil(t, err)
+	call = decoded.Contracts[0].Call
+	require.Equal(t, "transfer(address,uint256)", call.Method)
+	require.Len(t, call.Args, 2)
+	require.Equal(t, "_to", call.Args[0].Name)
+	require.Equal(t, recipient, call.Args[0].Value)
+	require.Equal(t, "250000", call.Args[1].Value)
+	require.Equal(t, common.BytesToHexString(data), decoded.Contracts[0].Parameter["data"])
+}
+
+func TestDecodeMemo(t *testing.T) {
+	tx := transaction(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: mustDecode(t, owner),
+		ToAddress:    mustDecode(t, recipient),
+		Amount:       1,
+	})
+	decoded, err := txdecode.New(nil).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Empty(t, decoded.Memo)
+
+	tx.RawData.Data = []byte("order #1234")
+	decoded, err = txdecode.New(nil).Decode(context.Background(), tx)
+	require.Nil(t, err)
+	require.Equal(t, "order #1234", decoded.Memo)
+	require.Equal(t, "0x00ff", txdecode.Memo([]byte{0x00, 0xff}))
+}
+
+func TestUnpackUnknown(t *testing.T) {
+	_, err := txdecode.Unpack(&core.Transaction_Contract{Type: core.Transaction_Contract_CustomContract})
+	require.NotNil(t, err)
+}
+
+func TestFormatAmount(t *testing.T) {
+	for raw, want := range map[int64]string{
+		0:          "0",
+		1:          "0.000001",
+		1_000_000:  "1",
+		12_345_678: "12.345678",
+		-500_000:   "-0.5",
+	} {
+		require.Equal(t, want, txdecode.FormatAmount(raw, 6))
+	}
+	require.Equal(t, "42", txdecode.FormatAmount(42, 0))
+}